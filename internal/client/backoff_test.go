@@ -0,0 +1,65 @@
+package client
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCalculateBackoffStrategies(t *testing.T) {
+	tests := []struct {
+		strategy string
+		want     []time.Duration
+	}{
+		{"", []time.Duration{1, 2, 4, 8, 10, 10}},
+		{BackoffExponential, []time.Duration{1, 2, 4, 8, 10, 10}},
+		{BackoffLinear, []time.Duration{1, 2, 3, 4, 5, 6}},
+		{BackoffConstant, []time.Duration{1, 1, 1, 1, 1, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.strategy, func(t *testing.T) {
+			c := &Client{RetryConfig: RetryConfig{
+				BaseDelay: time.Second,
+				MaxDelay:  10 * time.Second,
+				Strategy:  tt.strategy,
+			}}
+			for attempt, want := range tt.want {
+				if got := c.calculateBackoff(attempt); got != want*time.Second {
+					t.Errorf("calculateBackoff(%d) = %s, want %s", attempt, got, want*time.Second)
+				}
+			}
+		})
+	}
+}
+
+func TestCalculateBackoffLinearCappedAtMaxDelay(t *testing.T) {
+	c := &Client{RetryConfig: RetryConfig{
+		BaseDelay: time.Second,
+		MaxDelay:  3 * time.Second,
+		Strategy:  BackoffLinear,
+	}}
+	if got := c.calculateBackoff(10); got != 3*time.Second {
+		t.Errorf("calculateBackoff(10) = %s, want %s", got, 3*time.Second)
+	}
+}
+
+func TestCalculateBackoffStaysWithinBounds(t *testing.T) {
+	// Large attempt counts used to overflow to +Inf and wrap negative
+	c := &Client{RetryConfig: RetryConfig{
+		BaseDelay: time.Second,
+		MaxDelay:  30 * time.Second,
+	}}
+	for attempt := 0; attempt <= 40; attempt++ {
+		got := c.calculateBackoff(attempt)
+		if got < c.RetryConfig.BaseDelay || got > c.RetryConfig.MaxDelay {
+			t.Errorf("calculateBackoff(%d) = %s, want within [%s, %s]", attempt, got, c.RetryConfig.BaseDelay, c.RetryConfig.MaxDelay)
+		}
+	}
+
+	for _, attempt := range []int{1024, math.MaxInt32} {
+		if got := c.calculateBackoff(attempt); got != c.RetryConfig.MaxDelay {
+			t.Errorf("calculateBackoff(%d) = %s, want %s", attempt, got, c.RetryConfig.MaxDelay)
+		}
+	}
+}
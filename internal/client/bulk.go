@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultBulkConcurrency is the default number of parallel assign/unassign calls in bulk operations
+const DefaultBulkConcurrency = 8
+
+// AssignSeats assigns seats to many users in parallel using up to
+// concurrency workers. It returns the errors of failed users keyed by
+// git_user_id. The seats cache is bypassed for the whole batch.
+func (c *Client) AssignSeats(ctx context.Context, gitUserIDs []string, concurrency int) map[string]error {
+	return c.runBulk(ctx, gitUserIDs, concurrency, c.assignSeat)
+}
+
+// UnassignSeats unassigns seats from many users in parallel using up to
+// concurrency workers. It returns the errors of failed users keyed by
+// git_user_id. The seats cache is bypassed for the whole batch.
+func (c *Client) UnassignSeats(ctx context.Context, gitUserIDs []string, concurrency int) map[string]error {
+	return c.runBulk(ctx, gitUserIDs, concurrency, c.unassignSeat)
+}
+
+// runBulk applies op to every ID with a bounded worker pool. Requests still
+// go through doRequestCtx, so the client's rate limiter and circuit breaker
+// apply across workers.
+func (c *Client) runBulk(ctx context.Context, gitUserIDs []string, concurrency int, op func(context.Context, string) error) map[string]error {
+	failed := make(map[string]error)
+	if len(gitUserIDs) == 0 {
+		return failed
+	}
+	if concurrency <= 0 {
+		concurrency = DefaultBulkConcurrency
+	}
+
+	c.beginSeatsMutation()
+	defer c.endSeatsMutation()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	jobs := make(chan string)
+
+	for i := 0; i < concurrency && i < len(gitUserIDs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for gitUserID := range jobs {
+				if err := op(ctx, gitUserID); err != nil {
+					mu.Lock()
+					failed[gitUserID] = err
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for _, gitUserID := range gitUserIDs {
+		jobs <- gitUserID
+	}
+	close(jobs)
+	wg.Wait()
+
+	return failed
+}
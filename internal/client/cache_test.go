@@ -0,0 +1,187 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// seatsServer returns a handler serving a single assigned seat and counting
+// seats requests
+func seatsServer(requests *atomic.Int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(`{"users":[{"git_user_id":"1","seat_assigned":true}]}`))
+	}
+}
+
+// fakeClock is a manually advanced clock for cache expiry
+type fakeClock struct {
+	now time.Time
+}
+
+func (f *fakeClock) Now() time.Time {
+	return f.now
+}
+
+func (f *fakeClock) Advance(d time.Duration) {
+	f.now = f.now.Add(d)
+}
+
+func TestGetSeatsCacheTTL(t *testing.T) {
+	var requests atomic.Int32
+	c := newTestClient(t, seatsServer(&requests))
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	c.now = clock.Now
+	c.CacheTTL = time.Minute
+
+	ctx := context.Background()
+	getSeats := func(want int32) {
+		t.Helper()
+		if _, err := c.GetSeats(ctx); err != nil {
+			t.Fatalf("GetSeats() error = %v", err)
+		}
+		if got := requests.Load(); got != want {
+			t.Errorf("seats requests = %d, want %d", got, want)
+		}
+	}
+
+	getSeats(1)
+	clock.Advance(59 * time.Second)
+	getSeats(1)
+	clock.Advance(time.Second)
+	getSeats(2)
+	getSeats(2)
+
+	c.InvalidateSeatsCache()
+	getSeats(3)
+}
+
+func TestGetSeatsCacheWithoutTTLNeverExpires(t *testing.T) {
+	var requests atomic.Int32
+	c := newTestClient(t, seatsServer(&requests))
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	c.now = clock.Now
+	c.CacheTTL = 0
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		if _, err := c.GetSeats(ctx); err != nil {
+			t.Fatalf("GetSeats() error = %v", err)
+		}
+		clock.Advance(24 * time.Hour)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("seats requests = %d, want 1", got)
+	}
+}
+
+func TestHasSeatIndexRebuiltAfterInvalidation(t *testing.T) {
+	assigned := "1"
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"users":[{"git_user_id":"` + assigned + `","seat_assigned":true},{"git_user_id":"3","seat_assigned":false}]}`))
+	}))
+
+	ctx := context.Background()
+	hasSeat := func(gitUserID string, want bool) {
+		t.Helper()
+		got, err := c.HasSeat(ctx, gitUserID)
+		if err != nil {
+			t.Fatalf("HasSeat(%q) error = %v", gitUserID, err)
+		}
+		if got != want {
+			t.Errorf("HasSeat(%q) = %v, want %v", gitUserID, got, want)
+		}
+	}
+
+	hasSeat("1", true)
+	hasSeat("2", false)
+	hasSeat("3", false)
+
+	assigned = "2"
+	c.InvalidateSeatsCache()
+	hasSeat("1", false)
+	hasSeat("2", true)
+}
+
+// largeSeatsServer returns a handler serving n assigned seats with
+// git_user_ids 1 through n
+func largeSeatsServer(n int) http.HandlerFunc {
+	type user struct {
+		GitUserID    string `json:"git_user_id"`
+		SeatAssigned bool   `json:"seat_assigned"`
+	}
+	users := make([]user, n)
+	for i := range users {
+		users[i] = user{GitUserID: strconv.Itoa(i + 1), SeatAssigned: true}
+	}
+	body, _ := json.Marshal(map[string]any{"users": users})
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(body)
+	}
+}
+
+// BenchmarkHasSeat looks up every seat of a 5k-user response through the
+// index, the way a bulk apply checks each of its users
+func BenchmarkHasSeat(b *testing.B) {
+	const users = 5000
+	server := httptest.NewServer(largeSeatsServer(users))
+	defer server.Close()
+	c := NewClient("test-api-key", server.URL, "", "test")
+	defer c.Close()
+
+	ctx := context.Background()
+	if _, err := c.GetSeats(ctx); err != nil {
+		b.Fatalf("GetSeats() error = %v", err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for id := 1; id <= users; id++ {
+			if ok, err := c.HasSeat(ctx, strconv.Itoa(id)); err != nil || !ok {
+				b.Fatalf("HasSeat(%d) = %v, %v", id, ok, err)
+			}
+		}
+	}
+}
+
+// BenchmarkHasSeatLinearScan is the same lookup as BenchmarkHasSeat by
+// scanning the users list, as HasSeat did before the index
+func BenchmarkHasSeatLinearScan(b *testing.B) {
+	const users = 5000
+	server := httptest.NewServer(largeSeatsServer(users))
+	defer server.Close()
+	c := NewClient("test-api-key", server.URL, "", "test")
+	defer c.Close()
+
+	ctx := context.Background()
+	if _, err := c.GetSeats(ctx); err != nil {
+		b.Fatalf("GetSeats() error = %v", err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for id := 1; id <= users; id++ {
+			seats, err := c.GetSeats(ctx)
+			if err != nil {
+				b.Fatalf("GetSeats() error = %v", err)
+			}
+			gitUserID, found := strconv.Itoa(id), false
+			for _, user := range seats.Users {
+				if user.GitUserID == gitUserID && user.SeatAssigned {
+					found = true
+					break
+				}
+			}
+			if !found {
+				b.Fatalf("seat %s not found", gitUserID)
+			}
+		}
+	}
+}
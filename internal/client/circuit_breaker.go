@@ -0,0 +1,106 @@
+package client
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned when the circuit breaker is open because the
+// CodeRabbit API has failed persistently, so requests fail fast instead of
+// retrying
+var ErrCircuitOpen = errors.New("circuit breaker open: CodeRabbit API is failing persistently")
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuitBreaker tracks consecutive retryable failures shared by all
+// requests made through a Client. The zero value is a closed breaker.
+type circuitBreaker struct {
+	mu           sync.Mutex
+	state        circuitState
+	failures     int
+	firstFailure time.Time
+	openedAt     time.Time
+	probing      bool
+	probeStarted time.Time
+}
+
+// allow reports whether a request may be attempted. After the cooldown an
+// open breaker becomes half-open and lets a single probe request through.
+func (b *circuitBreaker) allow(cfg RetryConfig) error {
+	if cfg.CircuitBreakerThreshold <= 0 {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case circuitOpen:
+		if time.Since(b.openedAt) < cfg.CircuitBreakerCooldown {
+			return ErrCircuitOpen
+		}
+		b.state = circuitHalfOpen
+		b.probing = true
+		b.probeStarted = time.Now()
+		return nil
+	case circuitHalfOpen:
+		// A probe that never reported back (e.g. cancelled) must not keep
+		// the breaker stuck, so allow a new one after another cooldown
+		if b.probing && time.Since(b.probeStarted) < cfg.CircuitBreakerCooldown {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+		b.probeStarted = time.Now()
+		return nil
+	default:
+		return nil
+	}
+}
+
+// recordSuccess closes the breaker and resets the failure count
+func (b *circuitBreaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = circuitClosed
+	b.failures = 0
+	b.probing = false
+}
+
+// recordFailure counts a retryable failure and opens the breaker once the
+// threshold is reached within the configured window
+func (b *circuitBreaker) recordFailure(cfg RetryConfig) {
+	if cfg.CircuitBreakerThreshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+
+	if b.state == circuitHalfOpen {
+		b.state = circuitOpen
+		b.openedAt = now
+		b.probing = false
+		return
+	}
+
+	if b.failures == 0 || now.Sub(b.firstFailure) > cfg.CircuitBreakerWindow {
+		b.failures = 0
+		b.firstFailure = now
+	}
+	b.failures++
+
+	if b.failures >= cfg.CircuitBreakerThreshold {
+		b.state = circuitOpen
+		b.openedAt = now
+	}
+}
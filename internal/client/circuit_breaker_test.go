@@ -0,0 +1,121 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingHandler answers every request with status and counts them
+func countingHandler(status int, requests *atomic.Int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"users":[]}`))
+	}
+}
+
+func TestCircuitBreakerFailsFastDuringOutage(t *testing.T) {
+	var requests atomic.Int32
+	c := newTestClient(t, countingHandler(http.StatusServiceUnavailable, &requests))
+	c.DisableCache = true
+	c.RetryConfig.MaxRetries = 5
+	c.RetryConfig.CircuitBreakerThreshold = 3
+	c.RetryConfig.CircuitBreakerCooldown = time.Hour
+
+	ctx := context.Background()
+	if _, err := c.GetSeats(ctx); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("GetSeats() error = %v, want ErrCircuitOpen once the threshold is reached", err)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("got %d requests during the outage, want 3", got)
+	}
+
+	start := time.Now()
+	if _, err := c.GetSeats(ctx); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("GetSeats() error = %v, want ErrCircuitOpen", err)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("got %d requests, want no request while the circuit is open", got)
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("GetSeats() took %s with an open circuit, want it to fail fast", elapsed)
+	}
+}
+
+func TestCircuitBreakerHalfOpenProbeCloses(t *testing.T) {
+	var failing atomic.Bool
+	failing.Store(true)
+	var requests atomic.Int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if failing.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{"users":[]}`))
+	}))
+	c.DisableCache = true
+	c.RetryConfig.MaxRetries = 0
+	c.RetryConfig.CircuitBreakerThreshold = 1
+	c.RetryConfig.CircuitBreakerCooldown = 20 * time.Millisecond
+
+	ctx := context.Background()
+	if _, err := c.GetSeats(ctx); err == nil {
+		t.Fatal("GetSeats() error = nil, want the 502")
+	}
+	if _, err := c.GetSeats(ctx); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("GetSeats() error = %v, want ErrCircuitOpen", err)
+	}
+
+	failing.Store(false)
+	time.Sleep(30 * time.Millisecond)
+	if _, err := c.GetSeats(ctx); err != nil {
+		t.Fatalf("GetSeats() after cooldown error = %v, want the probe to succeed", err)
+	}
+	if _, err := c.GetSeats(ctx); err != nil {
+		t.Fatalf("GetSeats() after the probe error = %v, want a closed circuit", err)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("got %d requests, want 3", got)
+	}
+}
+
+func TestCircuitBreakerIgnoresRateLimits(t *testing.T) {
+	var requests atomic.Int32
+	c := newTestClient(t, countingHandler(http.StatusTooManyRequests, &requests))
+	c.DisableCache = true
+	c.RetryConfig.MaxRetries = 4
+	c.RetryConfig.CircuitBreakerThreshold = 2
+	c.RetryConfig.CircuitBreakerCooldown = time.Hour
+
+	_, err := c.GetSeats(context.Background())
+	if !IsRateLimited(err) || errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("GetSeats() error = %v, want the 429 without opening the circuit", err)
+	}
+	if got := requests.Load(); got != 5 {
+		t.Errorf("got %d requests, want every retry to be attempted", got)
+	}
+}
+
+func TestCircuitBreakerDisabledByDefault(t *testing.T) {
+	if threshold := DefaultRetryConfig().CircuitBreakerThreshold; threshold != 0 {
+		t.Fatalf("DefaultRetryConfig().CircuitBreakerThreshold = %d, want 0", threshold)
+	}
+
+	var requests atomic.Int32
+	c := newTestClient(t, countingHandler(http.StatusServiceUnavailable, &requests))
+	c.DisableCache = true
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.GetSeats(context.Background()); errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("GetSeats() error = %v, want no circuit breaker by default", err)
+		}
+	}
+	if want := int32(3 * (c.RetryConfig.MaxRetries + 1)); requests.Load() != want {
+		t.Errorf("got %d requests, want %d", requests.Load(), want)
+	}
+}
@@ -2,59 +2,351 @@ package client
 
 import (
 	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math"
+	"math/rand"
 	"net/http"
+	"net/url"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/time/rate"
 )
 
 // RetryConfig holds retry configuration
 type RetryConfig struct {
-	MaxRetries  int
-	BaseDelay   time.Duration
-	MaxDelay    time.Duration
+	MaxRetries           int
+	BaseDelay            time.Duration
+	MaxDelay             time.Duration
 	RetryableStatusCodes []int
+
+	// MaxElapsed bounds the total time a single call spends on attempts and
+	// backoff. No retry is started once the next backoff would exceed it, and
+	// the last error is returned. Zero means no limit beyond MaxRetries.
+	MaxElapsed time.Duration
+
+	// Budget caps the total number of retries across every request made by
+	// the client, so a widespread outage can't multiply retries across
+	// hundreds of resources. Once spent, failed requests are not retried.
+	// Zero means unlimited.
+	Budget int
+
+	// Strategy selects how the backoff delay grows with each retry: one of
+	// BackoffExponential (the default when empty), BackoffLinear or
+	// BackoffConstant. Every strategy is capped at MaxDelay.
+	Strategy string
+
+	// Jitter randomizes each backoff delay to a value in [0, computed] so
+	// concurrent callers don't retry in lockstep
+	Jitter bool
+
+	// Rand returns a pseudo-random number in [0.0, 1.0) used for jitter.
+	// Defaults to math/rand when nil.
+	Rand func() float64
+
+	// RequestsPerSecond limits the rate of CodeRabbit API requests issued by
+	// the client. Zero means unlimited.
+	RequestsPerSecond float64
+
+	// CircuitBreakerThreshold is the number of consecutive retryable failures
+	// within CircuitBreakerWindow after which requests fail fast with
+	// ErrCircuitOpen. Rate limited (429) responses are not failures. Zero,
+	// the default, disables the circuit breaker.
+	CircuitBreakerThreshold int
+	CircuitBreakerWindow    time.Duration
+
+	// CircuitBreakerCooldown is how long the breaker stays open before
+	// letting a single probe request through
+	CircuitBreakerCooldown time.Duration
 }
 
+// Backoff strategies for RetryConfig.Strategy
+const (
+	BackoffExponential = "exponential"
+	BackoffLinear      = "linear"
+	BackoffConstant    = "constant"
+)
+
+// BackoffStrategies lists the valid values of RetryConfig.Strategy
+var BackoffStrategies = []string{BackoffExponential, BackoffLinear, BackoffConstant}
+
 // DefaultRetryConfig returns sensible default retry settings
 func DefaultRetryConfig() RetryConfig {
 	return RetryConfig{
-		MaxRetries:  3,
-		BaseDelay:   1 * time.Second,
-		MaxDelay:    30 * time.Second,
+		MaxRetries:           3,
+		BaseDelay:            1 * time.Second,
+		MaxDelay:             30 * time.Second,
 		RetryableStatusCodes: []int{429, 500, 502, 503, 504},
+		Jitter:               true,
+
+		CircuitBreakerWindow:   1 * time.Minute,
+		CircuitBreakerCooldown: 30 * time.Second,
 	}
 }
 
+// DefaultAPIVersion is the API version prefix used when none is configured
+const DefaultAPIVersion = "v1"
+
+// Seat roles accepted by the assign endpoint
+const (
+	SeatRoleReviewer = "reviewer"
+	SeatRoleAdmin    = "admin"
+)
+
+// Default seat endpoint paths, relative to the API version prefix
+const (
+	DefaultSeatsPath        = "/seats/"
+	DefaultAssignSeatPath   = "/seats/assign"
+	DefaultUnassignSeatPath = "/seats/unassign"
+)
+
+// DefaultRequestTimeout is the default per-attempt HTTP client timeout
+const DefaultRequestTimeout = 30 * time.Second
+
+// DefaultMaxResponseBytes is the default limit on the size of a response body
+const DefaultMaxResponseBytes = 10 << 20
+
+// DefaultCacheTTL is the default lifetime of the cached seats response
+const DefaultCacheTTL = 60 * time.Second
+
+// DefaultSeatWaitTimeout is how long WaitForSeat polls by default for an
+// assignment to become visible
+const DefaultSeatWaitTimeout = 30 * time.Second
+
+// Bounds of the delay between WaitForSeat polls
+const (
+	seatWaitBaseDelay = 500 * time.Millisecond
+	seatWaitMaxDelay  = 5 * time.Second
+)
+
 // Client is the CodeRabbit API client
 type Client struct {
 	APIKey      string
 	BaseURL     string
 	GitHubToken string
+	UserAgent   string
+
+	// FallbackBaseURL is a secondary CodeRabbit API host used when BaseURL is
+	// unreachable. Empty disables failover.
+	FallbackBaseURL string
+
+	// APIVersion is the path prefix inserted between BaseURL and the endpoint
+	// path, e.g. "v1". An empty value omits the prefix entirely.
+	APIVersion string
+
+	// Seat endpoint paths, relative to the API version prefix. They default
+	// to DefaultSeatsPath, DefaultAssignSeatPath and DefaultUnassignSeatPath
+	// and only need changing when CodeRabbit serves seats from other routes.
+	SeatsPath        string
+	AssignSeatPath   string
+	UnassignSeatPath string
+
+	// GitHubBaseURL is the GitHub REST API root, e.g. https://api.github.com
+	// or https://github.example.com/api/v3 for GitHub Enterprise Server
+	GitHubBaseURL string
+
+	// Credentials and API roots for GitLab and Bitbucket user resolution
+	GitLabToken      string
+	GitLabBaseURL    string
+	BitbucketToken   string
+	BitbucketBaseURL string
+
 	HTTPClient  *http.Client
 	RetryConfig RetryConfig
 
-	// Cache for seats response (valid for single terraform run)
-	seatsCache   *SeatsResponse
+	// MaxResponseBytes limits the size of every API response body read by
+	// the client, so a misbehaving endpoint can't exhaust memory. Larger
+	// responses fail with ErrResponseTooLarge. Zero or negative means no limit.
+	MaxResponseBytes int64
+
+	// GitHubHTTPClient is used for GitHub API calls so that CodeRabbit-only
+	// transport settings (such as skipping TLS verification) don't leak to GitHub
+	GitHubHTTPClient *http.Client
+
+	// DefaultHeaders are added to every outbound API request, e.g. headers
+	// required by a corporate gateway. Authentication and the headers the
+	// client sets itself always take precedence.
+	DefaultHeaders map[string]string
+
+	// RequestObserver, when set, is called after every HTTP attempt to the
+	// CodeRabbit, GitHub, GitLab and Bitbucket APIs, e.g. to record metrics.
+	// statusCode is 0 when no response was received. It is called from
+	// concurrent requests and must be safe for concurrent use.
+	RequestObserver func(method, path string, statusCode int, attempt int, duration time.Duration, err error)
+
+	// ValidateGitHubScopes makes MissingGitHubTokenScopes check the scopes of
+	// a classic GitHubToken
+	ValidateGitHubScopes bool
+
+	// DryRun skips seat assign and unassign calls, logging them instead and
+	// reporting success. Reads still hit the API.
+	DryRun bool
+
+	// SeatWaitTimeout bounds how long callers wait via WaitForSeat for a new
+	// assignment to show up in the seats list. Zero disables waiting.
+	SeatWaitTimeout time.Duration
+
+	// SeatsReadTimeout and SeatsWriteTimeout replace HTTPClient.Timeout for
+	// each attempt of a seats page request and of a seat assign or unassign
+	// request respectively. Zero, the default, falls back to HTTPClient.Timeout
+	// so that request_timeout applies to seat calls unless these are set.
+	SeatsReadTimeout  time.Duration
+	SeatsWriteTimeout time.Duration
+
+	// CacheTTL is how long a fetched seats response is reused before
+	// GetSeats fetches it again. Zero or negative means it never expires.
+	CacheTTL time.Duration
+
+	// DisableCache makes GetSeats and GetRepositories fetch on every call
+	// without storing results, for workflows that change seats outside
+	// Terraform during a run
+	DisableCache bool
+
+	// now returns the current time for cache expiry; nil means time.Now.
+	// Tests replace it with a fake clock.
+	now func() time.Time
+
+	// Cache for seats responses per organization, keyed by seatsCacheKey
+	seatsCache   map[string]seatsCacheEntry
 	seatsCacheMu sync.RWMutex
+
+	// Number of seat assign/unassign calls in flight, guarded by seatsCacheMu.
+	// The seats cache is bypassed while it is non-zero, since a response
+	// fetched mid-mutation may or may not reflect it.
+	seatsMutations int
+
+	// Cache for repositories responses per organization, keyed like seatsCache
+	repositoriesCache   map[string]repositoriesCacheEntry
+	repositoriesCacheMu sync.RWMutex
+
+	// Cache for GitHub username to user ID lookups, keyed by lowercased username,
+	// and the reverse user ID to login mapping
+	userCache   map[string]string
+	loginCache  map[string]string
+	userCacheMu sync.RWMutex
+
+	// OAuth scopes of GitHubToken, read once by MissingGitHubTokenScopes
+	githubScopes      []string
+	githubScopesKnown bool
+	githubScopesRead  bool
+	githubScopesMu    sync.Mutex
+
+	// Rate limiter shared by all requests, built lazily from RetryConfig
+	limiter     *rate.Limiter
+	limiterOnce sync.Once
+
+	// Circuit breakers shared by all requests to BaseURL and FallbackBaseURL
+	breaker         circuitBreaker
+	fallbackBreaker circuitBreaker
+
+	// Whether FallbackBaseURL answered after BaseURL was unreachable, so
+	// requests should go there first
+	useFallback atomic.Bool
+
+	// Retries spent against RetryConfig.Budget
+	retriesUsed atomic.Int64
+
+	// GitHub App credentials, used instead of GitHubToken when set
+	githubApp *githubApp
+
+	// OIDC token exchange settings, used instead of APIKey when set
+	oidc *oidcAuth
+
+	// Transports backing HTTPClient and GitHubHTTPClient
+	transport       *http.Transport
+	githubTransport *http.Transport
 }
 
 // NewClient creates a new CodeRabbit API client
-func NewClient(apiKey, baseURL, githubToken string) *Client {
+func NewClient(apiKey, baseURL, githubToken, version string) *Client {
+	transport := newTransport()
+	githubTransport := newTransport()
+
 	return &Client{
 		APIKey:      apiKey,
 		BaseURL:     baseURL,
 		GitHubToken: githubToken,
+		UserAgent:   "terraform-provider-coderabbit/" + version,
+		APIVersion:  DefaultAPIVersion,
+
+		SeatsPath:        DefaultSeatsPath,
+		AssignSeatPath:   DefaultAssignSeatPath,
+		UnassignSeatPath: DefaultUnassignSeatPath,
+
+		GitHubBaseURL:    DefaultGitHubBaseURL,
+		GitLabBaseURL:    DefaultGitLabBaseURL,
+		BitbucketBaseURL: DefaultBitbucketBaseURL,
 		HTTPClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:   DefaultRequestTimeout,
+			Transport: transport,
 		},
 		RetryConfig: DefaultRetryConfig(),
+		CacheTTL:    DefaultCacheTTL,
+
+		MaxResponseBytes: DefaultMaxResponseBytes,
+
+		SeatWaitTimeout: DefaultSeatWaitTimeout,
+
+		GitHubHTTPClient: &http.Client{
+			Timeout:       DefaultRequestTimeout,
+			Transport:     githubTransport,
+			CheckRedirect: checkGitHubRedirect,
+		},
+		transport:       transport,
+		githubTransport: githubTransport,
 	}
 }
 
+// SetRequestTimeout sets the timeout of each CodeRabbit and GitHub HTTP
+// attempt. Zero disables the client-level timeout so that only the request
+// context deadline applies; a shorter context deadline always wins.
+func (c *Client) SetRequestTimeout(timeout time.Duration) {
+	c.HTTPClient.Timeout = timeout
+	c.GitHubHTTPClient.Timeout = timeout
+}
+
+// reservedHeaders lists headers that DefaultHeaders may not set
+var reservedHeaders = []string{"x-coderabbitai-api-key", "Authorization", "Host", "Idempotency-Key"}
+
+// IsReservedHeader reports whether name is a header DefaultHeaders may not set
+func IsReservedHeader(name string) bool {
+	for _, reserved := range reservedHeaders {
+		if strings.EqualFold(name, reserved) {
+			return true
+		}
+	}
+	return false
+}
+
+// setDefaultHeaders adds DefaultHeaders to req. It must be called before the
+// client's own headers are set so that those win.
+func (c *Client) setDefaultHeaders(req *http.Request) {
+	for name, value := range c.DefaultHeaders {
+		if !IsReservedHeader(name) {
+			req.Header.Set(name, value)
+		}
+	}
+}
+
+// apiURL builds the full CodeRabbit API URL for path on baseURL, including
+// the APIVersion prefix when set
+func (c *Client) apiURL(baseURL, path string) string {
+	if c.APIVersion == "" {
+		return baseURL + path
+	}
+	return baseURL + "/" + c.APIVersion + path
+}
+
 // isRetryableStatus checks if the status code should trigger a retry
 func (c *Client) isRetryableStatus(statusCode int) bool {
 	for _, code := range c.RetryConfig.RetryableStatusCodes {
@@ -65,12 +357,45 @@ func (c *Client) isRetryableStatus(statusCode int) bool {
 	return false
 }
 
-// calculateBackoff returns the delay for the given attempt using exponential backoff
+// rateLimiter returns the client's request rate limiter, or nil when unlimited
+func (c *Client) rateLimiter() *rate.Limiter {
+	c.limiterOnce.Do(func() {
+		if c.RetryConfig.RequestsPerSecond > 0 {
+			c.limiter = rate.NewLimiter(rate.Limit(c.RetryConfig.RequestsPerSecond), 1)
+		}
+	})
+	return c.limiter
+}
+
+// calculateBackoff returns the delay for the given attempt using the
+// configured backoff strategy, with full jitter applied when enabled
 func (c *Client) calculateBackoff(attempt int) time.Duration {
-	delay := time.Duration(float64(c.RetryConfig.BaseDelay) * math.Pow(2, float64(attempt)))
-	if delay > c.RetryConfig.MaxDelay {
-		delay = c.RetryConfig.MaxDelay
+	var factor float64
+	switch c.RetryConfig.Strategy {
+	case BackoffConstant:
+		factor = 1
+	case BackoffLinear:
+		factor = float64(attempt + 1)
+	default:
+		factor = math.Pow(2, float64(attempt))
+	}
+
+	// Clamp in float space before converting: for large attempts the product
+	// overflows to +Inf, which would wrap to a negative time.Duration
+	raw := float64(c.RetryConfig.BaseDelay) * factor
+	delay := c.RetryConfig.MaxDelay
+	if !math.IsInf(raw, 0) && !math.IsNaN(raw) && raw >= 0 && raw < float64(c.RetryConfig.MaxDelay) {
+		delay = time.Duration(raw)
+	}
+
+	if c.RetryConfig.Jitter {
+		randFloat := c.RetryConfig.Rand
+		if randFloat == nil {
+			randFloat = rand.Float64
+		}
+		delay = time.Duration(randFloat() * float64(delay))
 	}
+
 	return delay
 }
 
@@ -78,16 +403,108 @@ func (c *Client) calculateBackoff(attempt int) time.Duration {
 type SeatUser struct {
 	GitUserID    string `json:"git_user_id"`
 	SeatAssigned bool   `json:"seat_assigned"`
+
+	// AssignedAt is the RFC3339 time the seat was assigned, when reported by the API
+	AssignedAt string `json:"assigned_at,omitempty"`
+
+	// Role is the seat tier, e.g. reviewer or admin, when reported by the API
+	Role string `json:"role,omitempty"`
+}
+
+// seatUserJSON accepts both the snake_case field names of the seats API and
+// the camelCase ones some endpoints and API versions use instead
+type seatUserJSON struct {
+	GitUserID    json.RawMessage `json:"git_user_id"`
+	SeatAssigned *bool           `json:"seat_assigned"`
+	AssignedAt   string          `json:"assigned_at"`
+	Role         string          `json:"role"`
+
+	UserID        json.RawMessage `json:"userId"`
+	HasSeat       *bool           `json:"hasSeat"`
+	AssignedAtAlt string          `json:"assignedAt"`
+}
+
+// UnmarshalJSON decodes a seat user written with either naming convention.
+// The snake_case field wins when both are present. User IDs may be JSON
+// strings or numbers.
+func (u *SeatUser) UnmarshalJSON(data []byte) error {
+	var raw seatUserJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	idField := raw.GitUserID
+	if len(idField) == 0 || string(idField) == "null" {
+		idField = raw.UserID
+	}
+	gitUserID, err := decodeUserID(idField)
+	if err != nil {
+		return fmt.Errorf("invalid seat user ID %s: %w", idField, err)
+	}
+
+	assigned := raw.SeatAssigned
+	if assigned == nil {
+		assigned = raw.HasSeat
+	}
+	assignedAt := raw.AssignedAt
+	if assignedAt == "" {
+		assignedAt = raw.AssignedAtAlt
+	}
+
+	*u = SeatUser{
+		GitUserID:    gitUserID,
+		SeatAssigned: assigned != nil && *assigned,
+		AssignedAt:   assignedAt,
+		Role:         raw.Role,
+	}
+	return nil
+}
+
+// decodeUserID decodes a user ID given as a JSON string or number. A missing
+// or null ID decodes to "".
+func decodeUserID(data json.RawMessage) (string, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return "", nil
+	}
+
+	var id string
+	if err := json.Unmarshal(data, &id); err == nil {
+		return id, nil
+	}
+
+	var number json.Number
+	if err := json.Unmarshal(data, &number); err != nil {
+		return "", err
+	}
+	return number.String(), nil
 }
 
 // SeatsResponse represents the response from GET /seats/
 type SeatsResponse struct {
 	Users []SeatUser `json:"users"`
+
+	// SeatLimit is the maximum number of seats, when reported by the API
+	SeatLimit *int64 `json:"seat_limit,omitempty"`
+
+	// NextCursor is the cursor of the next page of a paginated response
+	NextCursor string `json:"next_cursor,omitempty"`
+
+	// index maps git_user_id to users with assigned seats, built lazily by
+	// Client.seatIndex under seatsCacheMu
+	index map[string]SeatUser
+}
+
+// SubscriptionResponse represents the response from GET /subscription
+type SubscriptionResponse struct {
+	Plan      string `json:"plan"`
+	SeatLimit int64  `json:"seat_limit"`
+	SeatsUsed int64  `json:"seats_used"`
 }
 
 // AssignSeatRequest represents the request body for POST /seats/assign
 type AssignSeatRequest struct {
 	GitUserID string `json:"git_user_id"`
+	Role      string `json:"role,omitempty"`
 }
 
 // UnassignSeatRequest represents the request body for POST /seats/unassign
@@ -95,9 +512,23 @@ type UnassignSeatRequest struct {
 	GitUserID string `json:"git_user_id"`
 }
 
-// SuccessResponse represents a successful API response
+// SuccessResponse represents a successful API response. Failed responses may
+// explain themselves with a message or an errors list.
 type SuccessResponse struct {
-	Success bool `json:"success"`
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+	Errors  []struct {
+		Message string `json:"message"`
+	} `json:"errors,omitempty"`
+}
+
+// messages returns every failure message of the response
+func (r *SuccessResponse) messages() []string {
+	messages := (&ErrorResponse{Errors: r.Errors}).messages()
+	if r.Message != "" {
+		messages = append([]string{r.Message}, messages...)
+	}
+	return messages
 }
 
 // ErrorResponse represents an error API response
@@ -108,34 +539,142 @@ type ErrorResponse struct {
 }
 
 func (e *ErrorResponse) Error() string {
-	if len(e.Errors) > 0 {
-		return e.Errors[0].Message
+	if len(e.Errors) == 0 {
+		return "unknown error"
+	}
+	return strings.Join(e.messages(), "; ")
+}
+
+// messages returns every error message in the response, in order
+func (e *ErrorResponse) messages() []string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		messages = append(messages, err.Message)
 	}
-	return "unknown error"
+	return messages
 }
 
-// GitHubUserResponse represents the response from GitHub API
-type GitHubUserResponse struct {
-	ID    int    `json:"id"`
-	Login string `json:"login"`
+// retryBudgetExceeded reports whether sleeping for delay before another
+// attempt would take a call started at started past RetryConfig.MaxElapsed
+func (c *Client) retryBudgetExceeded(started time.Time, delay time.Duration) bool {
+	return c.RetryConfig.MaxElapsed > 0 && time.Since(started)+delay > c.RetryConfig.MaxElapsed
 }
 
-// doRequest performs an HTTP request to the CodeRabbit API with retry logic
-func (c *Client) doRequest(method, path string, body any) ([]byte, error) {
+// takeRetry spends one retry from the client-wide RetryConfig.Budget,
+// reporting false when the budget is exhausted
+func (c *Client) takeRetry() bool {
+	if c.RetryConfig.Budget <= 0 {
+		return true
+	}
+	if c.retriesUsed.Add(1) > int64(c.RetryConfig.Budget) {
+		c.retriesUsed.Add(-1)
+		return false
+	}
+	return true
+}
+
+// sleepCtx waits for the given duration or until the context is done
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// doRequestCtx performs an HTTP request to the CodeRabbit API with retry logic.
+// The request and any backoff between retries are aborted when ctx is done.
+// A 204 No Content or empty response body returns nil bytes and no error.
+// When FallbackBaseURL is set and the current host is unreachable, the whole
+// request is retried once against the other host, which is then preferred.
+func (c *Client) doRequestCtx(ctx context.Context, method, path string, body any) ([]byte, error) {
+	respBody, _, err := c.doRequestWithHeaderCtx(ctx, method, path, body)
+	return respBody, err
+}
+
+// doRequestWithHeaderCtx is doRequestCtx that also returns the response
+// headers of the successful attempt
+func (c *Client) doRequestWithHeaderCtx(ctx context.Context, method, path string, body any) ([]byte, http.Header, error) {
 	var jsonBody []byte
 	var err error
 
 	if body != nil {
 		jsonBody, err = json.Marshal(body)
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+			return nil, nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
 	}
 
+	if c.FallbackBaseURL == "" {
+		respBody, header, _, err := c.doHostRequestCtx(ctx, c.BaseURL, &c.breaker, method, path, jsonBody)
+		return respBody, header, err
+	}
+
+	primary, secondary := c.BaseURL, c.FallbackBaseURL
+	primaryBreaker, secondaryBreaker := &c.breaker, &c.fallbackBreaker
+	useFallback := c.useFallback.Load()
+	if useFallback {
+		primary, secondary = secondary, primary
+		primaryBreaker, secondaryBreaker = secondaryBreaker, primaryBreaker
+	}
+
+	respBody, header, unreachable, err := c.doHostRequestCtx(ctx, primary, primaryBreaker, method, path, jsonBody)
+	if !unreachable {
+		return respBody, header, err
+	}
+
+	tflog.Warn(ctx, "CodeRabbit API host unreachable, failing over", map[string]interface{}{
+		"from":  primary,
+		"to":    secondary,
+		"error": err.Error(),
+	})
+
+	respBody, header, unreachable, fallbackErr := c.doHostRequestCtx(ctx, secondary, secondaryBreaker, method, path, jsonBody)
+	if !unreachable {
+		// Keep preferring whichever host answered for the rest of the run
+		c.useFallback.Store(!useFallback)
+		return respBody, header, fallbackErr
+	}
+
+	return nil, nil, fmt.Errorf("%w (%s also failed: %v)", fallbackErr, primary, err)
+}
+
+// doHostRequestCtx performs the request against a single CodeRabbit API host
+// with retry logic. It also reports whether the host was unreachable, i.e. the
+// last failure happened at the connection level or the host's circuit is open.
+func (c *Client) doHostRequestCtx(ctx context.Context, baseURL string, breaker *circuitBreaker, method, path string, jsonBody []byte) ([]byte, http.Header, bool, error) {
 	var lastErr error
+	lastErrUnreachable := false
+	started := time.Now()
 	for attempt := 0; attempt <= c.RetryConfig.MaxRetries; attempt++ {
 		if attempt > 0 {
-			time.Sleep(c.calculateBackoff(attempt - 1))
+			delay := c.calculateBackoff(attempt - 1)
+			if c.retryBudgetExceeded(started, delay) {
+				return nil, nil, lastErrUnreachable, fmt.Errorf("request failed after %d attempts, retry time budget of %s exhausted: %w", attempt, c.RetryConfig.MaxElapsed, lastErr)
+			}
+			if !c.takeRetry() {
+				return nil, nil, false, fmt.Errorf("request failed after %d attempts, %w: %w", attempt, ErrRetryBudgetExhausted, lastErr)
+			}
+			if err := sleepCtx(ctx, delay); err != nil {
+				return nil, nil, false, fmt.Errorf("request cancelled: %w", err)
+			}
+		}
+
+		if err := breaker.allow(c.RetryConfig); err != nil {
+			if lastErr != nil {
+				return nil, nil, true, fmt.Errorf("%w (last error: %v)", err, lastErr)
+			}
+			return nil, nil, true, err
+		}
+
+		if limiter := c.rateLimiter(); limiter != nil {
+			if err := limiter.Wait(ctx); err != nil {
+				return nil, nil, false, fmt.Errorf("request cancelled while rate limited: %w", err)
+			}
 		}
 
 		var reqBody io.Reader
@@ -143,108 +682,151 @@ func (c *Client) doRequest(method, path string, body any) ([]byte, error) {
 			reqBody = bytes.NewBuffer(jsonBody)
 		}
 
-		req, err := http.NewRequest(method, c.BaseURL+"/v1"+path, reqBody)
+		req, err := http.NewRequestWithContext(ctx, method, c.apiURL(baseURL, path), reqBody)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create request: %w", err)
+			return nil, nil, false, fmt.Errorf("failed to create request: %w", err)
 		}
 
-		req.Header.Set("x-coderabbitai-api-key", c.APIKey)
+		apiKey, err := c.apiCredential(ctx)
+		if err != nil {
+			return nil, nil, false, err
+		}
+
+		c.setDefaultHeaders(req)
+		req.Header.Set("x-coderabbitai-api-key", apiKey)
+		if key := idempotencyKey(ctx); key != "" {
+			req.Header.Set("Idempotency-Key", key)
+		}
 		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", c.UserAgent)
 
-		resp, err := c.HTTPClient.Do(req)
+		attemptCtx, httpClient, cancel := c.attemptClient(ctx)
+		start := time.Now()
+		resp, err := httpClient.Do(req.WithContext(attemptCtx))
 		if err != nil {
+			cancel()
+			c.logAttempt(ctx, req, attempt, 0, time.Since(start), err)
+			if ctx.Err() != nil {
+				return nil, nil, false, fmt.Errorf("request cancelled: %w", ctx.Err())
+			}
+			breaker.recordFailure(c.RetryConfig)
+			if !isRetryableNetworkError(err) {
+				return nil, nil, true, fmt.Errorf("failed to perform request: %w", err)
+			}
 			lastErr = fmt.Errorf("failed to perform request: %w", err)
+			lastErrUnreachable = true
 			continue
 		}
 
-		respBody, err := io.ReadAll(resp.Body)
-		_ = resp.Body.Close()
+		respBody, err := c.readBody(resp)
+		cancel()
+		c.logAttempt(ctx, req, attempt, resp.StatusCode, time.Since(start), err)
+		if errors.Is(err, ErrResponseTooLarge) {
+			return nil, nil, false, err
+		}
 		if err != nil {
+			breaker.recordFailure(c.RetryConfig)
 			lastErr = fmt.Errorf("failed to read response body: %w", err)
+			lastErrUnreachable = true
 			continue
 		}
 
 		if c.isRetryableStatus(resp.StatusCode) {
-			lastErr = fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(respBody))
+			// A rate limited API is up and only throttling, so 429s are
+			// retried without counting towards opening the circuit
+			if resp.StatusCode == http.StatusTooManyRequests {
+				breaker.recordSuccess()
+			} else {
+				breaker.recordFailure(c.RetryConfig)
+			}
+			lastErr = newAPIError(resp.StatusCode, respBody, requestID(resp.Header))
+			lastErrUnreachable = false
 			continue
 		}
 
+		// Any non-retryable response means the API is reachable
+		breaker.recordSuccess()
+
 		if resp.StatusCode >= 400 {
-			var errResp ErrorResponse
-			if err := json.Unmarshal(respBody, &errResp); err != nil {
-				return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(respBody))
-			}
-			return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, errResp.Error())
+			return nil, nil, false, newAPIError(resp.StatusCode, respBody, requestID(resp.Header))
 		}
 
-		return respBody, nil
-	}
-
-	return nil, fmt.Errorf("request failed after %d retries: %w", c.RetryConfig.MaxRetries, lastErr)
-}
-
-// GetGitUserID resolves a GitHub username to a numeric user ID with retry logic
-func (c *Client) GetGitUserID(githubID string) (string, error) {
-	var lastErr error
-
-	for attempt := 0; attempt <= c.RetryConfig.MaxRetries; attempt++ {
-		if attempt > 0 {
-			time.Sleep(c.calculateBackoff(attempt - 1))
+		// No Content and empty bodies are successes with nothing to decode
+		if resp.StatusCode == http.StatusNoContent || len(bytes.TrimSpace(respBody)) == 0 {
+			return nil, resp.Header, false, nil
 		}
 
-		req, err := http.NewRequest(http.MethodGet, "https://api.github.com/users/"+githubID, nil)
-		if err != nil {
-			return "", fmt.Errorf("failed to create GitHub API request: %w", err)
-		}
+		return respBody, resp.Header, false, nil
+	}
 
-		req.Header.Set("Accept", "application/vnd.github+json")
-		if c.GitHubToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.GitHubToken)
-		}
+	return nil, nil, lastErrUnreachable, fmt.Errorf("request failed after %d retries: %w", c.RetryConfig.MaxRetries, lastErr)
+}
 
-		resp, err := c.HTTPClient.Do(req)
-		if err != nil {
-			lastErr = fmt.Errorf("failed to perform GitHub API request: %w", err)
-			continue
-		}
+// readBody reads and closes a response body, failing with ErrResponseTooLarge
+// when it exceeds MaxResponseBytes
+func (c *Client) readBody(resp *http.Response) ([]byte, error) {
+	defer resp.Body.Close()
 
-		respBody, err := io.ReadAll(resp.Body)
-		_ = resp.Body.Close()
-		if err != nil {
-			lastErr = fmt.Errorf("failed to read GitHub API response: %w", err)
-			continue
-		}
+	if c.MaxResponseBytes <= 0 {
+		return io.ReadAll(resp.Body)
+	}
 
-		if resp.StatusCode == 404 {
-			return "", fmt.Errorf("GitHub user '%s' not found", githubID)
-		}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, c.MaxResponseBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > c.MaxResponseBytes {
+		return nil, fmt.Errorf("%w: %s %s returned more than %d bytes", ErrResponseTooLarge, resp.Request.Method, resp.Request.URL.Path, c.MaxResponseBytes)
+	}
+	return body, nil
+}
 
-		if c.isRetryableStatus(resp.StatusCode) {
-			lastErr = fmt.Errorf("GitHub API error (status %d)", resp.StatusCode)
-			continue
-		}
+// seatsCacheEntry is a cached seats response and the time it was fetched
+type seatsCacheEntry struct {
+	seats     *SeatsResponse
+	fetchedAt time.Time
+}
 
-		if resp.StatusCode >= 400 {
-			return "", fmt.Errorf("GitHub API error (status %d)", resp.StatusCode)
-		}
+// seatsCacheKey identifies the organization an API key belongs to, so that a
+// client used with several API keys never serves one organization's seats
+// for another. The key is hashed so it is not kept as a map key in clear.
+func (c *Client) seatsCacheKey(apiKey string) string {
+	sum := sha256.Sum256([]byte(c.BaseURL + "\x00" + apiKey))
+	return hex.EncodeToString(sum[:])
+}
 
-		var user GitHubUserResponse
-		if err := json.Unmarshal(respBody, &user); err != nil {
-			return "", fmt.Errorf("failed to parse GitHub API response: %w", err)
-		}
+// cachedSeats returns the cached seats response for key if it can be reused.
+// Callers must hold seatsCacheMu.
+func (c *Client) cachedSeats(key string) (*SeatsResponse, bool) {
+	if c.DisableCache || c.seatsMutations > 0 {
+		return nil, false
+	}
 
-		return fmt.Sprintf("%d", user.ID), nil
+	entry, ok := c.seatsCache[key]
+	if !ok {
+		return nil, false
+	}
+	if c.CacheTTL > 0 && c.clock().Sub(entry.fetchedAt) >= c.CacheTTL {
+		return nil, false
 	}
+	return entry.seats, true
+}
 
-	return "", fmt.Errorf("GitHub API request failed after %d retries: %w", c.RetryConfig.MaxRetries, lastErr)
+// clock returns the current time used for cache expiry
+func (c *Client) clock() time.Time {
+	if c.now != nil {
+		return c.now()
+	}
+	return time.Now()
 }
 
-// GetSeats retrieves all seat assignments (cached for the lifetime of the client)
-func (c *Client) GetSeats() (*SeatsResponse, error) {
+// GetSeats retrieves all seat assignments (cached per API key for CacheTTL)
+func (c *Client) GetSeats(ctx context.Context) (*SeatsResponse, error) {
+	key := c.seatsCacheKey(c.APIKey)
+
 	// Check cache first with read lock
 	c.seatsCacheMu.RLock()
-	if c.seatsCache != nil {
-		cached := c.seatsCache
+	if cached, ok := c.cachedSeats(key); ok {
 		c.seatsCacheMu.RUnlock()
 		return cached, nil
 	}
@@ -255,89 +837,408 @@ func (c *Client) GetSeats() (*SeatsResponse, error) {
 	defer c.seatsCacheMu.Unlock()
 
 	// Double-check after acquiring write lock
-	if c.seatsCache != nil {
-		return c.seatsCache, nil
+	if cached, ok := c.cachedSeats(key); ok {
+		return cached, nil
 	}
 
-	respBody, err := c.doRequest(http.MethodGet, "/seats/", nil)
+	seats, err := c.fetchSeats(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	// Don't cache a response that may predate an in-flight mutation
+	if c.DisableCache || c.seatsMutations > 0 {
+		return seats, nil
+	}
+
+	if c.seatsCache == nil {
+		c.seatsCache = make(map[string]seatsCacheEntry)
+	}
+	c.seatsCache[key] = seatsCacheEntry{seats: seats, fetchedAt: c.clock()}
+	return seats, nil
+}
+
+// fetchSeats retrieves every page of seat assignments. Pages are followed via
+// a next_cursor field in the body or a Link header with rel="next". A 404 for
+// the first page means the organization has no seats yet and returns an empty
+// response; any other error status, or a 404 for a later page, is an error.
+func (c *Client) fetchSeats(ctx context.Context) (*SeatsResponse, error) {
 	var seats SeatsResponse
-	if err := json.Unmarshal(respBody, &seats); err != nil {
+	path := c.SeatsPath
+	seen := make(map[string]bool)
+	ctx = withRequestTimeout(ctx, c.SeatsReadTimeout)
+
+	for page := 1; ; page++ {
+		respBody, header, err := c.doRequestWithHeaderCtx(ctx, http.MethodGet, path, nil)
+		if page == 1 && IsNotFound(err) {
+			// Organizations that never had a seat have no seats list yet
+			tflog.Warn(ctx, "CodeRabbit seats endpoint returned 404, treating the organization as having no seats", map[string]interface{}{
+				"path": path,
+			})
+			return &SeatsResponse{}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var pageResp SeatsResponse
+		if err := json.Unmarshal(respBody, &pageResp); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		if err := checkSeatsShape(respBody); err != nil {
+			return nil, err
+		}
+
+		seats.Users = append(seats.Users, pageResp.Users...)
+		if pageResp.SeatLimit != nil {
+			seats.SeatLimit = pageResp.SeatLimit
+		}
+
+		next := nextPage(c.SeatsPath, pageResp.NextCursor, header)
+		if next == "" {
+			return &seats, nil
+		}
+		if seen[next] || page >= maxSeatsPages {
+			return nil, fmt.Errorf("seats pagination did not terminate after %d pages", page)
+		}
+		seen[next] = true
+		path = next
+	}
+}
+
+// checkSeatsShape returns an error when a seats response has fields but no
+// users field, e.g. because the API wrapped it in another object. Decoding
+// such a body would otherwise report every user as seatless.
+func checkSeatsShape(body []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if _, ok := fields["users"]; ok || len(fields) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return fmt.Errorf("unexpected seats response from the CodeRabbit API: no \"users\" field (found %s); the API version may not match this provider, check api_version or upgrade the provider", strings.Join(keys, ", "))
+}
+
+// maxSeatsPages bounds how many seats pages fetchSeats follows
+const maxSeatsPages = 1000
+
+// nextPage returns the path of the next page of the paginated endpoint at
+// basePath, or "" on the last page
+func nextPage(basePath, cursor string, header http.Header) string {
+	if cursor != "" {
+		return basePath + "?cursor=" + url.QueryEscape(cursor)
+	}
+
+	// Only the query of the Link target is used, so pages are fetched from
+	// whichever host served the first page
+	for _, link := range header.Values("Link") {
+		for _, part := range strings.Split(link, ",") {
+			target, params, ok := strings.Cut(part, ";")
+			if !ok || !strings.Contains(params, `rel="next"`) {
+				continue
+			}
+			next, err := url.Parse(strings.Trim(strings.TrimSpace(target), "<>"))
+			if err != nil || next.RawQuery == "" {
+				continue
+			}
+			return basePath + "?" + next.RawQuery
+		}
+	}
+
+	return ""
+}
+
+// Ping checks that the CodeRabbit API is reachable and accepts the API key. It
+// requests a single seat and bypasses the seats cache. Like GetSeats, a 404
+// means an organization without a seats list yet, so the key was accepted.
+func (c *Client) Ping(ctx context.Context) error {
+	_, err := c.doRequestCtx(ctx, http.MethodGet, c.SeatsPath+"?limit=1", nil)
+	if IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// GetSubscription retrieves the organization's subscription plan and seat usage
+func (c *Client) GetSubscription(ctx context.Context) (*SubscriptionResponse, error) {
+	respBody, err := c.doRequestCtx(ctx, http.MethodGet, "/subscription", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var subscription SubscriptionResponse
+	if err := json.Unmarshal(respBody, &subscription); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
-	c.seatsCache = &seats
-	return &seats, nil
+	return &subscription, nil
 }
 
-// InvalidateSeatsCache clears the seats cache, forcing a fresh fetch on next GetSeats call
-func (c *Client) InvalidateSeatsCache() {
+// InvalidateSeatsCache clears the cached seats of the organizations owning
+// the given API keys, or of the client's own APIKey when none are given,
+// forcing a fresh fetch on next GetSeats call
+func (c *Client) InvalidateSeatsCache(apiKeys ...string) {
+	if len(apiKeys) == 0 {
+		apiKeys = []string{c.APIKey}
+	}
+
 	c.seatsCacheMu.Lock()
 	defer c.seatsCacheMu.Unlock()
+	for _, apiKey := range apiKeys {
+		delete(c.seatsCache, c.seatsCacheKey(apiKey))
+	}
+}
+
+// ForceRefresh discards every cached seats and repositories response, so the
+// next GetSeats or GetRepositories fetches from the API even within CacheTTL.
+// It is safe to call concurrently with requests.
+func (c *Client) ForceRefresh() {
+	c.seatsCacheMu.Lock()
 	c.seatsCache = nil
+	c.seatsCacheMu.Unlock()
+
+	c.repositoriesCacheMu.Lock()
+	c.repositoriesCache = nil
+	c.repositoriesCacheMu.Unlock()
+}
+
+// RefreshOn calls ForceRefresh whenever trigger receives, e.g. from a file
+// watcher or signal handler of a tool that changes seats during a run. It
+// returns immediately; the trigger is watched until ctx is done or trigger
+// is closed.
+func (c *Client) RefreshOn(ctx context.Context, trigger <-chan struct{}) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-trigger:
+				if !ok {
+					return
+				}
+				tflog.Debug(ctx, "Refresh triggered, discarding cached seats")
+				c.ForceRefresh()
+			}
+		}
+	}()
+}
+
+// beginSeatsMutation marks a seat assign/unassign as in flight, invalidating
+// the seats cache so no reader is served a response from before it
+func (c *Client) beginSeatsMutation() {
+	c.seatsCacheMu.Lock()
+	defer c.seatsCacheMu.Unlock()
+	c.seatsMutations++
+	delete(c.seatsCache, c.seatsCacheKey(c.APIKey))
+}
+
+// endSeatsMutation marks a seat assign/unassign begun with beginSeatsMutation
+// as finished, invalidating any response cached while it was in flight. It
+// must be called whether or not the mutation succeeded.
+func (c *Client) endSeatsMutation() {
+	c.seatsCacheMu.Lock()
+	defer c.seatsCacheMu.Unlock()
+	c.seatsMutations--
+	delete(c.seatsCache, c.seatsCacheKey(c.APIKey))
 }
 
 // AssignSeat assigns a seat to a user
-func (c *Client) AssignSeat(gitUserID string) error {
-	reqBody := AssignSeatRequest{GitUserID: gitUserID}
-	respBody, err := c.doRequest(http.MethodPost, "/seats/assign", reqBody)
-	if err != nil {
-		return err
-	}
+func (c *Client) AssignSeat(ctx context.Context, gitUserID string) error {
+	return c.AssignSeatWithRole(ctx, gitUserID, "")
+}
 
-	var success SuccessResponse
-	if err := json.Unmarshal(respBody, &success); err != nil {
-		return fmt.Errorf("failed to unmarshal response: %w", err)
-	}
+// AssignSeatWithRole assigns a seat with the given role to a user, or changes
+// the role of an existing seat. An empty role uses the API default.
+func (c *Client) AssignSeatWithRole(ctx context.Context, gitUserID, role string) error {
+	c.beginSeatsMutation()
+	defer c.endSeatsMutation()
 
-	if !success.Success {
-		return fmt.Errorf("seat assignment failed")
-	}
+	return c.assignSeatWithRole(ctx, gitUserID, role)
+}
 
-	// Invalidate cache since seat state changed
-	c.InvalidateSeatsCache()
+// assignSeat calls the assign endpoint with the default role without
+// touching the seats cache
+func (c *Client) assignSeat(ctx context.Context, gitUserID string) error {
+	return c.assignSeatWithRole(ctx, gitUserID, "")
+}
 
-	return nil
+// assignSeatWithRole calls the assign endpoint without touching the seats cache
+func (c *Client) assignSeatWithRole(ctx context.Context, gitUserID, role string) error {
+	if c.DryRun {
+		logDryRun(ctx, c.AssignSeatPath, gitUserID)
+		return nil
+	}
+
+	reqBody := AssignSeatRequest{GitUserID: gitUserID, Role: role}
+	return c.postSeatMutation(ctx, c.AssignSeatPath, reqBody, "seat assignment failed")
 }
 
 // UnassignSeat unassigns a seat from a user
-func (c *Client) UnassignSeat(gitUserID string) error {
+func (c *Client) UnassignSeat(ctx context.Context, gitUserID string) error {
+	c.beginSeatsMutation()
+	defer c.endSeatsMutation()
+
+	return c.unassignSeat(ctx, gitUserID)
+}
+
+// unassignSeat calls the unassign endpoint without touching the seats cache
+func (c *Client) unassignSeat(ctx context.Context, gitUserID string) error {
+	if c.DryRun {
+		logDryRun(ctx, c.UnassignSeatPath, gitUserID)
+		return nil
+	}
+
 	reqBody := UnassignSeatRequest{GitUserID: gitUserID}
-	respBody, err := c.doRequest(http.MethodPost, "/seats/unassign", reqBody)
+	return c.postSeatMutation(ctx, c.UnassignSeatPath, reqBody, "seat unassignment failed")
+}
+
+// postSeatMutation posts a seat assign/unassign request. A 200 response with
+// "success": false can be a transient backend condition, so the request is
+// retried with backoff up to MaxRetries times, separately from the HTTP
+// status retries of doRequestCtx. The final error is failure wrapping an
+// *APIError with status 200 and the messages the API reported.
+func (c *Client) postSeatMutation(ctx context.Context, path string, body any, failure string) error {
+	// The call is one logical operation, so every attempt, HTTP retry and
+	// failover reuses the same key and the API can deduplicate them
+	key, err := newIdempotencyKey()
 	if err != nil {
 		return err
 	}
+	reqCtx := withRequestTimeout(withIdempotencyKey(ctx, key), c.SeatsWriteTimeout)
 
-	var success SuccessResponse
-	if err := json.Unmarshal(respBody, &success); err != nil {
-		return fmt.Errorf("failed to unmarshal response: %w", err)
-	}
+	var lastErr *APIError
+	started := time.Now()
+	for attempt := 0; attempt <= c.RetryConfig.MaxRetries; attempt++ {
+		if attempt > 0 {
+			// MaxElapsed bounds the whole call, including success:false retries
+			delay := c.calculateBackoff(attempt - 1)
+			if c.retryBudgetExceeded(started, delay) {
+				return fmt.Errorf("%s after %d attempts, retry time budget of %s exhausted: %w", failure, attempt, c.RetryConfig.MaxElapsed, lastErr)
+			}
+			if !c.takeRetry() {
+				break
+			}
+			if err := sleepCtx(ctx, delay); err != nil {
+				return fmt.Errorf("request cancelled: %w", err)
+			}
+		}
 
-	if !success.Success {
-		return fmt.Errorf("seat unassignment failed")
-	}
+		respBody, header, err := c.doRequestWithHeaderCtx(reqCtx, http.MethodPost, path, body)
+		if err != nil {
+			return err
+		}
 
-	// Invalidate cache since seat state changed
-	c.InvalidateSeatsCache()
+		// An empty response (e.g. 204 No Content) means the request succeeded
+		if respBody == nil {
+			return nil
+		}
 
-	return nil
+		var success SuccessResponse
+		if err := json.Unmarshal(respBody, &success); err != nil {
+			return fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		if success.Success {
+			return nil
+		}
+
+		lastErr = newAPIError(http.StatusOK, respBody, requestID(header))
+		lastErr.Messages = success.messages()
+		tflog.Debug(ctx, "CodeRabbit API reported failure", map[string]interface{}{
+			"path":     path,
+			"attempt":  attempt,
+			"messages": strings.Join(lastErr.Messages, "; "),
+		})
+	}
+
+	if lastErr == nil {
+		return errors.New(failure)
+	}
+	return fmt.Errorf("%s: %w", failure, lastErr)
 }
 
 // HasSeat checks if a user has a seat assigned
-func (c *Client) HasSeat(gitUserID string) (bool, error) {
-	seats, err := c.GetSeats()
+func (c *Client) HasSeat(ctx context.Context, gitUserID string) (bool, error) {
+	user, err := c.GetSeatUser(ctx, gitUserID)
 	if err != nil {
 		return false, err
 	}
 
-	for _, user := range seats.Users {
-		if user.GitUserID == gitUserID && user.SeatAssigned {
-			return true, nil
+	return user != nil, nil
+}
+
+// WaitForSeat polls HasSeat, bypassing the seats cache, until the user's seat
+// is visible or timeout elapses. The API is eventually consistent, so a seat
+// may be missing from the seats list right after AssignSeat succeeds. A zero
+// or negative timeout, or DryRun, returns immediately.
+func (c *Client) WaitForSeat(ctx context.Context, gitUserID string, timeout time.Duration) error {
+	if timeout <= 0 || c.DryRun {
+		return nil
+	}
+
+	deadline := time.Now().Add(timeout)
+	delay := seatWaitBaseDelay
+	for {
+		c.InvalidateSeatsCache()
+		hasSeat, err := c.HasSeat(ctx, gitUserID)
+		if err != nil {
+			return err
+		}
+		if hasSeat {
+			return nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return fmt.Errorf("seat for git_user_id %s was not visible after %s", gitUserID, timeout)
 		}
+		if err := sleepCtx(ctx, min(delay, remaining)); err != nil {
+			return err
+		}
+		delay = min(delay*2, seatWaitMaxDelay)
+	}
+}
+
+// GetSeatUser returns the seat entry for a user with an assigned seat, or nil if the user has no seat
+func (c *Client) GetSeatUser(ctx context.Context, gitUserID string) (*SeatUser, error) {
+	seats, err := c.GetSeats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if user, ok := c.seatIndex(seats)[gitUserID]; ok {
+		return &user, nil
+	}
+
+	return nil, nil
+}
+
+// seatIndex returns the assigned-seat index of a seats response, building it on first use
+func (c *Client) seatIndex(seats *SeatsResponse) map[string]SeatUser {
+	c.seatsCacheMu.RLock()
+	index := seats.index
+	c.seatsCacheMu.RUnlock()
+	if index != nil {
+		return index
 	}
 
-	return false, nil
+	c.seatsCacheMu.Lock()
+	defer c.seatsCacheMu.Unlock()
+
+	if seats.index == nil {
+		index := make(map[string]SeatUser, len(seats.Users))
+		for _, user := range seats.Users {
+			if user.SeatAssigned {
+				index[user.GitUserID] = user
+			}
+		}
+		seats.index = index
+	}
+	return seats.index
 }
@@ -0,0 +1,259 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a client whose CodeRabbit and GitHub APIs are both
+// served by handler, with retries that don't sleep
+func newTestClient(t *testing.T, handler http.Handler) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	c := NewClient("test-api-key", server.URL, "", "test")
+	c.GitHubBaseURL = server.URL
+	c.RetryConfig.BaseDelay = time.Millisecond
+	c.RetryConfig.MaxDelay = time.Millisecond
+	c.RetryConfig.Jitter = false
+	t.Cleanup(c.Close)
+	return c
+}
+
+func TestAPIVersionPrefix(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{"v1", "/v1/seats/"},
+		{"v2", "/v2/seats/"},
+		{"", "/seats/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			var path string
+			c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.Path
+				_, _ = w.Write([]byte(`{"users":[]}`))
+			}))
+			c.APIVersion = tt.version
+
+			if got := c.apiURL(c.BaseURL, "/seats/"); got != c.BaseURL+tt.want {
+				t.Errorf("apiURL() = %q, want %q", got, c.BaseURL+tt.want)
+			}
+			if _, err := c.GetSeats(context.Background()); err != nil {
+				t.Fatalf("GetSeats() error = %v", err)
+			}
+			if path != tt.want {
+				t.Errorf("request path = %q, want %q", path, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeatMutationsAcceptEmptyResponses(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+	}{
+		{"204 No Content", http.StatusNoContent},
+		{"200 with empty body", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			c.RetryConfig.MaxRetries = 0
+
+			ctx := context.Background()
+			if err := c.AssignSeat(ctx, "12345"); err != nil {
+				t.Errorf("AssignSeat() error = %v", err)
+			}
+			if err := c.UnassignSeat(ctx, "12345"); err != nil {
+				t.Errorf("UnassignSeat() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestRequestTimeoutComposesWithContextDeadline(t *testing.T) {
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+	slow := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	})
+
+	// The client timeout bounds each attempt
+	c := newTestClient(t, slow)
+	c.RetryConfig.MaxRetries = 0
+	c.SetRequestTimeout(20 * time.Millisecond)
+	if _, err := c.GetSeats(context.Background()); err == nil {
+		t.Error("GetSeats() with a 20ms request timeout error = nil, want a timeout")
+	}
+
+	// Without a client timeout, the context deadline still applies
+	c = newTestClient(t, slow)
+	c.RetryConfig.MaxRetries = 0
+	c.SetRequestTimeout(0)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := c.GetSeats(ctx); err == nil {
+		t.Error("GetSeats() with no request timeout and a 20ms deadline error = nil, want a timeout")
+	}
+}
+
+func TestSeatTimeoutsOnlyApplyWhenSet(t *testing.T) {
+	c := NewClient("test-api-key", "https://api.example.com", "", "test")
+	defer c.Close()
+	if c.SeatsReadTimeout != 0 || c.SeatsWriteTimeout != 0 {
+		t.Errorf("default seat timeouts = %v, %v, want 0, 0", c.SeatsReadTimeout, c.SeatsWriteTimeout)
+	}
+	_, httpClient, cancel := c.attemptClient(withRequestTimeout(context.Background(), c.SeatsReadTimeout))
+	defer cancel()
+	if httpClient != c.HTTPClient {
+		t.Error("attemptClient() without a seat timeout replaced HTTPClient")
+	}
+
+	tests := []struct {
+		name           string
+		requestTimeout time.Duration
+		seatsTimeout   time.Duration
+		wantErr        bool
+	}{
+		{"request_timeout applies when unset", 20 * time.Millisecond, 0, true},
+		{"no limit when neither is set", 0, 0, false},
+		{"seat timeout replaces request_timeout", 20 * time.Millisecond, 5 * time.Second, false},
+		{"seat timeout applies without request_timeout", 0, 20 * time.Millisecond, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-time.After(100 * time.Millisecond):
+				case <-r.Context().Done():
+					return
+				}
+				if r.Method == http.MethodGet {
+					_, _ = w.Write([]byte(`{"users":[]}`))
+					return
+				}
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			c.RetryConfig.MaxRetries = 0
+			c.SetRequestTimeout(tt.requestTimeout)
+			c.SeatsReadTimeout = tt.seatsTimeout
+			c.SeatsWriteTimeout = tt.seatsTimeout
+
+			ctx := context.Background()
+			if _, err := c.GetSeats(ctx); (err != nil) != tt.wantErr {
+				t.Errorf("GetSeats() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := c.AssignSeat(ctx, "12345"); (err != nil) != tt.wantErr {
+				t.Errorf("AssignSeat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSeatEndpointPaths(t *testing.T) {
+	tests := []struct {
+		name                                string
+		seats, assign, unassign             string
+		wantSeats, wantAssign, wantUnassign string
+	}{
+		{"defaults", "", "", "", "/v1/seats/", "/v1/seats/assign", "/v1/seats/unassign"},
+		{"custom", "/orgs/acme/seats", "/orgs/acme/seats/add", "/orgs/acme/seats/remove", "/v1/orgs/acme/seats", "/v1/orgs/acme/seats/add", "/v1/orgs/acme/seats/remove"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var paths []string
+			c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				paths = append(paths, r.Method+" "+r.URL.Path)
+				mu.Unlock()
+				if r.Method == http.MethodGet {
+					_, _ = w.Write([]byte(`{"users":[]}`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"success":true}`))
+			}))
+			if tt.seats != "" {
+				c.SeatsPath, c.AssignSeatPath, c.UnassignSeatPath = tt.seats, tt.assign, tt.unassign
+			}
+
+			ctx := context.Background()
+			if _, err := c.GetSeats(ctx); err != nil {
+				t.Fatalf("GetSeats() error = %v", err)
+			}
+			if err := c.AssignSeat(ctx, "1"); err != nil {
+				t.Fatalf("AssignSeat() error = %v", err)
+			}
+			if err := c.UnassignSeat(ctx, "1"); err != nil {
+				t.Fatalf("UnassignSeat() error = %v", err)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			want := []string{"GET " + tt.wantSeats, "POST " + tt.wantAssign, "POST " + tt.wantUnassign}
+			if !reflect.DeepEqual(paths, want) {
+				t.Errorf("requests = %q, want %q", paths, want)
+			}
+		})
+	}
+}
+
+func TestSeatMutationRetriesSuccessFalse(t *testing.T) {
+	var requests atomic.Int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			_, _ = w.Write([]byte(`{"success":false,"message":"backend busy"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"success":true}`))
+	}))
+
+	if err := c.AssignSeat(context.Background(), "1"); err != nil {
+		t.Fatalf("AssignSeat() error = %v, want the retry to succeed", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("requests = %d, want 2", got)
+	}
+}
+
+func TestSeatMutationSuccessFalseExhaustsRetries(t *testing.T) {
+	var requests atomic.Int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(`{"success":false,"errors":[{"message":"seat limit reached"}]}`))
+	}))
+	c.RetryConfig.MaxRetries = 2
+
+	err := c.UnassignSeat(context.Background(), "1")
+	if err == nil {
+		t.Fatal("UnassignSeat() error = nil, want success:false to fail")
+	}
+	if !strings.Contains(err.Error(), "seat unassignment failed") || !strings.Contains(err.Error(), "seat limit reached") {
+		t.Errorf("UnassignSeat() error = %q, want the failure and the API message", err)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("requests = %d, want MaxRetries+1 = 3", got)
+	}
+}
@@ -0,0 +1,102 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// memorySeats serves the seats endpoints from an in-memory seat set
+type memorySeats struct {
+	mu       sync.Mutex
+	assigned map[string]bool
+}
+
+func (m *memorySeats) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	switch r.URL.Path {
+	case "/v1/seats/":
+		users := []map[string]any{}
+		for id := range m.assigned {
+			users = append(users, map[string]any{"git_user_id": id, "seat_assigned": true})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"users": users})
+	case "/v1/seats/assign", "/v1/seats/unassign":
+		var body struct {
+			GitUserID string `json:"git_user_id"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if r.URL.Path == "/v1/seats/assign" {
+			m.assigned[body.GitUserID] = true
+		} else {
+			delete(m.assigned, body.GitUserID)
+		}
+		_, _ = w.Write([]byte(`{"success":true}`))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestConcurrentSeatMutationsKeepCacheConsistent(t *testing.T) {
+	const users = 50
+	c := newTestClient(t, &memorySeats{assigned: make(map[string]bool)})
+	ctx := context.Background()
+
+	// Each user is assigned, and every other one unassigned again, while
+	// other goroutines keep reading and caching the seats list
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*users)
+	for i := 0; i < users; i++ {
+		wg.Add(2)
+		go func(id string, unassign bool) {
+			defer wg.Done()
+			if err := c.AssignSeat(ctx, id); err != nil {
+				errs <- err
+				return
+			}
+			if ok, err := c.HasSeat(ctx, id); err != nil || !ok {
+				errs <- fmt.Errorf("HasSeat(%s) after AssignSeat = %v, %v, want true", id, ok, err)
+				return
+			}
+			if !unassign {
+				return
+			}
+			if err := c.UnassignSeat(ctx, id); err != nil {
+				errs <- err
+				return
+			}
+			if ok, err := c.HasSeat(ctx, id); err != nil || ok {
+				errs <- fmt.Errorf("HasSeat(%s) after UnassignSeat = %v, %v, want false", id, ok, err)
+			}
+		}(strconv.Itoa(i), i%2 == 0)
+
+		go func() {
+			defer wg.Done()
+			if _, err := c.GetSeats(ctx); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	for i := 0; i < users; i++ {
+		id := strconv.Itoa(i)
+		ok, err := c.HasSeat(ctx, id)
+		if err != nil {
+			t.Fatalf("HasSeat(%s) error = %v", id, err)
+		}
+		if want := i%2 != 0; ok != want {
+			t.Errorf("final HasSeat(%s) = %v, want %v", id, ok, want)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+)
+
+// ErrRetryBudgetExhausted is returned when a request fails after the
+// client-wide RetryConfig.Budget has been spent, so it was not retried
+var ErrRetryBudgetExhausted = errors.New("provider-wide retry budget exhausted")
+
+// ErrResponseTooLarge is returned when a response body exceeds the client's
+// MaxResponseBytes limit
+var ErrResponseTooLarge = errors.New("response body too large")
+
+// APIError represents a non-successful HTTP response from the CodeRabbit API.
+// Messages holds every error message the API reported, in order.
+type APIError struct {
+	StatusCode int
+	Messages   []string
+	Body       []byte
+
+	// RequestID identifies the request for CodeRabbit support, when the
+	// response carried one
+	RequestID string
+}
+
+// requestIDHeaders lists response headers that carry a request ID, in order of preference
+var requestIDHeaders = []string{"X-Request-Id", "CF-Ray"}
+
+// requestID returns the request ID of a response, or "" if it has none
+func requestID(h http.Header) string {
+	for _, name := range requestIDHeaders {
+		if id := h.Get(name); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// newAPIError builds an APIError from a response status, body and request ID,
+// extracting any error messages the API reported
+func newAPIError(statusCode int, body []byte, requestID string) *APIError {
+	apiErr := &APIError{
+		StatusCode: statusCode,
+		Body:       body,
+		RequestID:  requestID,
+	}
+
+	var errResp ErrorResponse
+	if err := json.Unmarshal(body, &errResp); err == nil && len(errResp.Errors) > 0 {
+		apiErr.Messages = errResp.messages()
+	}
+
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	detail := string(e.Body)
+	if len(e.Messages) > 0 {
+		detail = strings.Join(e.Messages, "; ")
+	}
+	if e.RequestID != "" {
+		return fmt.Sprintf("API error (status %d, request ID %s): %s", e.StatusCode, e.RequestID, detail)
+	}
+	return fmt.Sprintf("API error (status %d): %s", e.StatusCode, detail)
+}
+
+// IsNotFound reports whether err is an APIError with a 404 status
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// IsUnauthorized reports whether err is an APIError with a 401 or 403 status
+func IsUnauthorized(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) &&
+		(apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden)
+}
+
+// IsRateLimited reports whether err is an APIError with a 429 status
+func IsRateLimited(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusTooManyRequests
+}
+
+// isRetryableNetworkError reports whether a transport-level error from
+// HTTPClient.Do is likely transient. Timeouts, connection resets and refusals
+// and truncated connections are retried; cancellation, unknown hosts and
+// anything else (e.g. TLS verification failures) fail fast.
+func isRetryableNetworkError(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return false
+	}
+
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -0,0 +1,151 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+)
+
+func TestAPIErrorReportsEveryMessage(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"errors":[{"message":"user is not an organization member"},{"message":"seat limit reached"}]}`))
+	}))
+
+	err := c.AssignSeat(context.Background(), "12345")
+	if err == nil {
+		t.Fatal("AssignSeat() error = nil, want the API errors")
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("AssignSeat() error = %T, want an *APIError in the chain", err)
+	}
+	want := []string{"user is not an organization member", "seat limit reached"}
+	if !reflect.DeepEqual(apiErr.Messages, want) {
+		t.Errorf("APIError.Messages = %q, want %q", apiErr.Messages, want)
+	}
+	for _, message := range want {
+		if !strings.Contains(err.Error(), message) {
+			t.Errorf("AssignSeat() error = %q, want it to contain %q", err, message)
+		}
+	}
+}
+
+func TestErrorResponseJoinsMessages(t *testing.T) {
+	var resp ErrorResponse
+	if err := json.Unmarshal([]byte(`{"errors":[{"message":"first"},{"message":"second"}]}`), &resp); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if got := resp.Error(); got != "first; second" {
+		t.Errorf("ErrorResponse.Error() = %q, want %q", got, "first; second")
+	}
+}
+
+// roundTripperFunc adapts a function to http.RoundTripper
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestIsRetryableNetworkError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"connection reset", &net.OpError{Op: "read", Err: syscall.ECONNRESET}, true},
+		{"connection refused", &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, true},
+		{"unexpected EOF", io.ErrUnexpectedEOF, true},
+		{"timeout", &net.DNSError{Err: "i/o timeout", IsTimeout: true}, true},
+		{"unknown host", &net.DNSError{Err: "no such host", Name: "api.invalid", IsNotFound: true}, false},
+		{"cancelled", fmt.Errorf("request: %w", context.Canceled), false},
+		{"other", errors.New("x509: certificate signed by unknown authority"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRetryableNetworkError(tt.err); got != tt.want {
+				t.Errorf("isRetryableNetworkError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNetworkErrorRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		wantAttempts int32
+	}{
+		{"unknown host fails fast", &net.DNSError{Err: "no such host", Name: "api.invalid", IsNotFound: true}, 1},
+		{"connection reset is retried", &net.OpError{Op: "read", Err: syscall.ECONNRESET}, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, http.NotFoundHandler())
+			c.RetryConfig.MaxRetries = 2
+
+			var attempts atomic.Int32
+			c.HTTPClient.Transport = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				attempts.Add(1)
+				return nil, tt.err
+			})
+
+			if _, err := c.GetSeats(context.Background()); err == nil {
+				t.Fatal("GetSeats() error = nil, want the network error")
+			}
+			if got := attempts.Load(); got != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", got, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestAPIErrorRequestID(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		wantID  string
+		wantErr string
+	}{
+		{"X-Request-Id", map[string]string{"X-Request-Id": "req-123", "CF-Ray": "ray-456"}, "req-123", "API error (status 400, request ID req-123): invalid git_user_id"},
+		{"CF-Ray", map[string]string{"CF-Ray": "ray-456"}, "ray-456", "API error (status 400, request ID ray-456): invalid git_user_id"},
+		{"absent", nil, "", "API error (status 400): invalid git_user_id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for name, value := range tt.headers {
+					w.Header().Set(name, value)
+				}
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"errors":[{"message":"invalid git_user_id"}]}`))
+			}))
+
+			_, err := c.GetSeats(context.Background())
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("GetSeats() error = %v, want an *APIError", err)
+			}
+			if apiErr.RequestID != tt.wantID {
+				t.Errorf("APIError.RequestID = %q, want %q", apiErr.RequestID, tt.wantID)
+			}
+			if got := apiErr.Error(); got != tt.wantErr {
+				t.Errorf("APIError.Error() = %q, want %q", got, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// droppingHandler counts requests and closes their connections without a
+// response, like a host that is down behind a load balancer
+func droppingHandler(requests *atomic.Int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err == nil {
+			conn.Close()
+		}
+	}
+}
+
+// newFallbackClient returns a client with primary as BaseURL and fallback as
+// FallbackBaseURL
+func newFallbackClient(t *testing.T, primary, fallback http.Handler) *Client {
+	t.Helper()
+
+	c := newTestClient(t, primary)
+	c.RetryConfig.MaxRetries = 1
+	server := httptest.NewServer(fallback)
+	t.Cleanup(server.Close)
+	c.FallbackBaseURL = server.URL
+	return c
+}
+
+func TestFallbackBaseURLWhenPrimaryIsDown(t *testing.T) {
+	var primaryRequests, fallbackRequests atomic.Int32
+	c := newFallbackClient(t, droppingHandler(&primaryRequests), seatsServer(&fallbackRequests))
+	c.DisableCache = true
+
+	ctx := context.Background()
+	seats, err := c.GetSeats(ctx)
+	if err != nil {
+		t.Fatalf("GetSeats() error = %v, want the fallback's response", err)
+	}
+	if len(seats.Users) != 1 {
+		t.Errorf("GetSeats() users = %v, want the fallback's seat", seats.Users)
+	}
+	if got := primaryRequests.Load(); got != 2 {
+		t.Errorf("primary requests = %d, want 2 (retries exhausted before failing over)", got)
+	}
+
+	// The fallback stays preferred for the rest of the run
+	if _, err := c.GetSeats(ctx); err != nil {
+		t.Fatalf("second GetSeats() error = %v", err)
+	}
+	if got := primaryRequests.Load(); got != 2 {
+		t.Errorf("primary requests after failover = %d, want 2", got)
+	}
+	if got := fallbackRequests.Load(); got != 2 {
+		t.Errorf("fallback requests = %d, want 2", got)
+	}
+}
+
+func TestFallbackBaseURLNotUsedForHTTPErrors(t *testing.T) {
+	var primaryRequests, fallbackRequests atomic.Int32
+	c := newFallbackClient(t, countingHandler(http.StatusBadRequest, &primaryRequests), seatsServer(&fallbackRequests))
+
+	if _, err := c.GetSeats(context.Background()); err == nil {
+		t.Fatal("GetSeats() error = nil, want the primary's 400")
+	}
+	if got := fallbackRequests.Load(); got != 0 {
+		t.Errorf("fallback requests = %d, want 0 when the primary answered", got)
+	}
+}
+
+func TestFallbackBaseURLBothDown(t *testing.T) {
+	var primaryRequests, fallbackRequests atomic.Int32
+	c := newFallbackClient(t, droppingHandler(&primaryRequests), droppingHandler(&fallbackRequests))
+
+	_, err := c.GetSeats(context.Background())
+	if err == nil {
+		t.Fatal("GetSeats() error = nil, want both hosts to fail")
+	}
+	if !strings.Contains(err.Error(), c.BaseURL) {
+		t.Errorf("GetSeats() error = %q, want it to name the primary host", err)
+	}
+	if primaryRequests.Load() == 0 || fallbackRequests.Load() == 0 {
+		t.Errorf("requests = %d primary, %d fallback, want both tried", primaryRequests.Load(), fallbackRequests.Load())
+	}
+}
@@ -0,0 +1,824 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// DefaultGitHubBaseURL is the REST API root for github.com
+const DefaultGitHubBaseURL = "https://api.github.com"
+
+// graphQLBatchSize is the maximum number of logins resolved per GraphQL query
+const graphQLBatchSize = 50
+
+// NormalizeGitHubBaseURL trims trailing slashes and appends the /api/v3 path
+// used by GitHub Enterprise Server when a GHES host is given without it
+func NormalizeGitHubBaseURL(baseURL string) string {
+	baseURL = strings.TrimRight(baseURL, "/")
+	if baseURL == "" {
+		return DefaultGitHubBaseURL
+	}
+
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Host == "api.github.com" {
+		return baseURL
+	}
+	if !strings.HasSuffix(u.Path, "/api/v3") {
+		baseURL += "/api/v3"
+	}
+	return baseURL
+}
+
+// maxGitHubRedirects bounds how many redirects a GitHub request follows
+const maxGitHubRedirects = 5
+
+// checkGitHubRedirect follows GitHub redirects, e.g. for renamed accounts,
+// keeping the Authorization header on redirects to the original host so the
+// request isn't downgraded to the unauthenticated rate limit, and dropping it
+// on any other host so the token never leaks
+func checkGitHubRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxGitHubRedirects {
+		return fmt.Errorf("stopped after %d GitHub API redirects", maxGitHubRedirects)
+	}
+
+	original := via[0]
+	if req.URL.Host == original.URL.Host {
+		if auth := original.Header.Get("Authorization"); auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+	} else {
+		req.Header.Del("Authorization")
+	}
+	return nil
+}
+
+// GitHubUserResponse represents the response from GitHub API
+type GitHubUserResponse struct {
+	ID    int    `json:"id"`
+	Login string `json:"login"`
+}
+
+// GitHubAPIError represents a non-successful HTTP response from the GitHub API.
+// Message and DocumentationURL are taken from GitHub's JSON error body, when present.
+type GitHubAPIError struct {
+	StatusCode       int
+	Body             []byte
+	Message          string
+	DocumentationURL string
+}
+
+// githubErrorResponse represents the JSON body of a GitHub API error
+type githubErrorResponse struct {
+	Message          string `json:"message"`
+	DocumentationURL string `json:"documentation_url"`
+}
+
+// newGitHubAPIError builds a GitHubAPIError from a response status and body,
+// extracting GitHub's error message
+func newGitHubAPIError(statusCode int, body []byte) *GitHubAPIError {
+	ghErr := &GitHubAPIError{StatusCode: statusCode, Body: body}
+
+	var errResp githubErrorResponse
+	if err := json.Unmarshal(body, &errResp); err == nil {
+		ghErr.Message = errResp.Message
+		ghErr.DocumentationURL = errResp.DocumentationURL
+	}
+
+	return ghErr
+}
+
+func (e *GitHubAPIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("GitHub API error (status %d)", e.StatusCode)
+	}
+	if e.DocumentationURL == "" {
+		return fmt.Sprintf("GitHub API error (status %d): %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("GitHub API error (status %d): %s (see %s)", e.StatusCode, e.Message, e.DocumentationURL)
+}
+
+// isGitHubStatus reports whether err is a GitHubAPIError with the given status
+func isGitHubStatus(err error, statusCode int) bool {
+	var ghErr *GitHubAPIError
+	return errors.As(err, &ghErr) && ghErr.StatusCode == statusCode
+}
+
+// isGitHubDotCom reports whether the client targets github.com rather than GHES
+func (c *Client) isGitHubDotCom() bool {
+	return c.GitHubBaseURL == DefaultGitHubBaseURL
+}
+
+// doGitHubRequestCtx performs an HTTP request to the GitHub API with retry logic.
+// path is relative to GitHubBaseURL unless it is an absolute URL.
+func (c *Client) doGitHubRequestCtx(ctx context.Context, method, path string, body any) ([]byte, error) {
+	respBody, _, err := c.doGitHubRequestWithHeaderCtx(ctx, method, path, body)
+	return respBody, err
+}
+
+// doGitHubRequestWithHeaderCtx is doGitHubRequestCtx that also returns the
+// response headers of the successful attempt
+func (c *Client) doGitHubRequestWithHeaderCtx(ctx context.Context, method, path string, body any) ([]byte, http.Header, error) {
+	var jsonBody []byte
+	if body != nil {
+		var err error
+		jsonBody, err = json.Marshal(body)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal GitHub API request body: %w", err)
+		}
+	}
+
+	reqURL := path
+	if !strings.HasPrefix(path, "https://") && !strings.HasPrefix(path, "http://") {
+		reqURL = c.GitHubBaseURL + path
+	}
+
+	var lastErr error
+	var rateLimitDelay time.Duration
+	started := time.Now()
+	for attempt := 0; attempt <= c.RetryConfig.MaxRetries; attempt++ {
+		if attempt > 0 {
+			delay := c.calculateBackoff(attempt - 1)
+			if rateLimitDelay > 0 {
+				delay = rateLimitDelay
+				rateLimitDelay = 0
+			}
+			if c.retryBudgetExceeded(started, delay) {
+				return nil, nil, fmt.Errorf("GitHub API request failed after %d attempts, retry time budget of %s exhausted: %w", attempt, c.RetryConfig.MaxElapsed, lastErr)
+			}
+			if !c.takeRetry() {
+				return nil, nil, fmt.Errorf("GitHub API request failed after %d attempts, %w: %w", attempt, ErrRetryBudgetExhausted, lastErr)
+			}
+			if err := sleepCtx(ctx, delay); err != nil {
+				return nil, nil, fmt.Errorf("GitHub API request cancelled: %w", err)
+			}
+		}
+
+		var reqBody io.Reader
+		if jsonBody != nil {
+			reqBody = bytes.NewBuffer(jsonBody)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, reqURL, reqBody)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create GitHub API request: %w", err)
+		}
+
+		token, err := c.githubAuthToken(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		c.setDefaultHeaders(req)
+		req.Header.Set("Accept", "application/vnd.github+json")
+		req.Header.Set("User-Agent", c.UserAgent)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		if jsonBody != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		start := time.Now()
+		resp, err := c.GitHubHTTPClient.Do(req)
+		if err != nil {
+			c.logAttempt(ctx, req, attempt, 0, time.Since(start), err)
+			if ctx.Err() != nil {
+				return nil, nil, fmt.Errorf("GitHub API request cancelled: %w", ctx.Err())
+			}
+			lastErr = fmt.Errorf("failed to perform GitHub API request: %w", err)
+			continue
+		}
+
+		respBody, err := c.readBody(resp)
+		c.logAttempt(ctx, req, attempt, resp.StatusCode, time.Since(start), err)
+		if errors.Is(err, ErrResponseTooLarge) {
+			return nil, nil, err
+		}
+		if err != nil {
+			lastErr = fmt.Errorf("failed to read GitHub API response: %w", err)
+			continue
+		}
+
+		if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests {
+			delay, limited, err := c.githubRateLimitDelay(resp.Header)
+			if err != nil {
+				return nil, nil, err
+			}
+			if limited {
+				lastErr = newGitHubAPIError(resp.StatusCode, respBody)
+				rateLimitDelay = delay
+				continue
+			}
+		}
+
+		if c.isRetryableStatus(resp.StatusCode) {
+			lastErr = newGitHubAPIError(resp.StatusCode, respBody)
+			continue
+		}
+
+		if resp.StatusCode >= 400 {
+			return nil, nil, newGitHubAPIError(resp.StatusCode, respBody)
+		}
+
+		return respBody, resp.Header, nil
+	}
+
+	return nil, nil, fmt.Errorf("GitHub API request failed after %d retries: %w", c.RetryConfig.MaxRetries, lastErr)
+}
+
+// githubRateLimitDelay inspects the rate limit headers of a 403/429 GitHub
+// response. It reports how long to wait before retrying when the response is
+// a rate limit, at most the maximum retry delay, and returns an error when
+// the primary rate limit is exhausted for longer than that.
+func (c *Client) githubRateLimitDelay(h http.Header) (time.Duration, bool, error) {
+	if h.Get("X-RateLimit-Remaining") == "0" {
+		var delay time.Duration
+		reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64)
+		if err == nil {
+			delay = time.Until(time.Unix(reset, 0))
+		}
+		if delay < 0 {
+			delay = 0
+		}
+
+		if err != nil || delay > c.RetryConfig.MaxDelay {
+			resetMsg := ""
+			if err == nil {
+				resetMsg = fmt.Sprintf(" (resets at %s)", time.Unix(reset, 0).UTC().Format(time.RFC3339))
+			}
+			if !c.HasGitHubAuth() {
+				return 0, false, fmt.Errorf("GitHub API rate limit exceeded%s; unauthenticated requests are limited to 60 per hour, set github_token (or GITHUB_TOKEN) for higher limits", resetMsg)
+			}
+			return 0, false, fmt.Errorf("GitHub API rate limit exceeded for the configured token%s", resetMsg)
+		}
+		return delay, true, nil
+	}
+
+	// Secondary rate limits report how long to wait via Retry-After. The wait
+	// is capped like any other retry delay so a large value can't stall the
+	// run; the caller's MaxElapsed check still applies to the capped delay.
+	if delay, ok := parseRetryAfter(h.Get("Retry-After")); ok {
+		return min(delay, c.RetryConfig.MaxDelay), true, nil
+	}
+
+	return 0, false, nil
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		delay := time.Until(t)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
+
+// GetGitHubTokenScopes returns the OAuth scopes granted to GitHubToken, as
+// reported in the X-OAuth-Scopes header of GET /user. ok is false when the
+// scopes are unknown: no token or a GitHub App is configured, or GitHub does
+// not report scopes for the token, as for fine-grained personal access tokens.
+func (c *Client) GetGitHubTokenScopes(ctx context.Context) (scopes []string, ok bool, err error) {
+	if c.githubApp != nil || c.GitHubToken == "" {
+		return nil, false, nil
+	}
+
+	_, header, err := c.doGitHubRequestWithHeaderCtx(ctx, http.MethodGet, "/user", nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	values, ok := header[http.CanonicalHeaderKey("X-OAuth-Scopes")]
+	if !ok {
+		return nil, false, nil
+	}
+	for _, value := range values {
+		for _, scope := range strings.Split(value, ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	return scopes, true, nil
+}
+
+// MissingGitHubTokenScopes returns which of the required OAuth scopes the
+// classic GitHubToken lacks, so that features needing them can warn before
+// GitHub fails with an error that doesn't name the scope. The token's scopes
+// are read once per client. It returns nil when ValidateGitHubScopes is false
+// or the scopes are unknown, e.g. for fine-grained tokens and GitHub Apps.
+func (c *Client) MissingGitHubTokenScopes(ctx context.Context, required ...string) ([]string, error) {
+	if !c.ValidateGitHubScopes {
+		return nil, nil
+	}
+
+	c.githubScopesMu.Lock()
+	defer c.githubScopesMu.Unlock()
+
+	// Failures are not cached so that a transient error is retried by the
+	// next caller
+	if !c.githubScopesRead {
+		scopes, ok, err := c.GetGitHubTokenScopes(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c.githubScopes, c.githubScopesKnown, c.githubScopesRead = scopes, ok, true
+	}
+
+	if !c.githubScopesKnown {
+		return nil, nil
+	}
+	return MissingGitHubScopes(c.githubScopes, required...), nil
+}
+
+// githubImpliedScopes maps OAuth scopes to the broader scopes that include them
+var githubImpliedScopes = map[string][]string{
+	"read:org":   {"write:org", "admin:org"},
+	"write:org":  {"admin:org"},
+	"user:email": {"user"},
+	"read:user":  {"user"},
+}
+
+// MissingGitHubScopes returns the scopes in required that granted neither
+// contains directly nor through a broader scope, e.g. admin:org for read:org
+func MissingGitHubScopes(granted []string, required ...string) []string {
+	has := make(map[string]bool, len(granted))
+	for _, scope := range granted {
+		has[scope] = true
+	}
+
+	var missing []string
+	for _, scope := range required {
+		if has[scope] {
+			continue
+		}
+		implied := false
+		for _, broader := range githubImpliedScopes[scope] {
+			if has[broader] {
+				implied = true
+				break
+			}
+		}
+		if !implied {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
+
+// GetGitUserID resolves a GitHub username to a numeric user ID (cached for the lifetime of the client)
+func (c *Client) GetGitUserID(ctx context.Context, githubID string) (string, error) {
+	if gitUserID, ok := c.cachedGitUserID(githubID); ok {
+		return gitUserID, nil
+	}
+
+	gitUserID, err := c.fetchGitUserID(ctx, githubID)
+	if err != nil {
+		return "", err
+	}
+
+	c.cacheGitUserID(githubID, gitUserID)
+	return gitUserID, nil
+}
+
+// InvalidateUserCache clears the GitHub user ID cache, forcing fresh lookups on next GetGitUserID call
+func (c *Client) InvalidateUserCache() {
+	c.userCacheMu.Lock()
+	defer c.userCacheMu.Unlock()
+	c.userCache = nil
+	c.loginCache = nil
+}
+
+// GetGitHubLogin reverse-resolves a numeric GitHub user ID to the user's
+// current login (cached for the lifetime of the client)
+func (c *Client) GetGitHubLogin(ctx context.Context, gitUserID string) (string, error) {
+	c.userCacheMu.RLock()
+	login, ok := c.loginCache[gitUserID]
+	c.userCacheMu.RUnlock()
+	if ok {
+		return login, nil
+	}
+
+	respBody, err := c.doGitHubRequestCtx(ctx, http.MethodGet, "/user/"+url.PathEscape(gitUserID), nil)
+	if err != nil {
+		if isGitHubStatus(err, http.StatusNotFound) {
+			return "", fmt.Errorf("GitHub user with ID %s not found", gitUserID)
+		}
+		return "", err
+	}
+
+	var user GitHubUserResponse
+	if err := json.Unmarshal(respBody, &user); err != nil {
+		return "", fmt.Errorf("failed to parse GitHub API response: %w", err)
+	}
+
+	c.cacheGitUserID(user.Login, gitUserID)
+	c.cacheGitHubLogin(gitUserID, user.Login)
+	return user.Login, nil
+}
+
+// GetGitHubLogins reverse-resolves many numeric GitHub user IDs to their
+// current logins, keyed by user ID, with one cached REST call per ID. When
+// some IDs cannot be resolved, e.g. because the account was deleted, the
+// resolved subset is returned together with an error naming the rest.
+func (c *Client) GetGitHubLogins(ctx context.Context, gitUserIDs []string) (map[string]string, error) {
+	logins := make(map[string]string, len(gitUserIDs))
+	seen := make(map[string]bool, len(gitUserIDs))
+	var failed []string
+	for _, gitUserID := range gitUserIDs {
+		if seen[gitUserID] {
+			continue
+		}
+		seen[gitUserID] = true
+
+		login, err := c.GetGitHubLogin(ctx, gitUserID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return logins, err
+			}
+			tflog.Debug(ctx, "Could not resolve GitHub user ID to a login", map[string]interface{}{
+				"git_user_id": gitUserID,
+				"error":       err.Error(),
+			})
+			failed = append(failed, gitUserID)
+			continue
+		}
+		logins[gitUserID] = login
+	}
+
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		return logins, fmt.Errorf("could not resolve GitHub user IDs: %s", strings.Join(failed, ", "))
+	}
+
+	return logins, nil
+}
+
+// CachedGitHubLogin returns the login of a user ID already resolved by this
+// client, without making a GitHub request
+func (c *Client) CachedGitHubLogin(gitUserID string) (string, bool) {
+	c.userCacheMu.RLock()
+	defer c.userCacheMu.RUnlock()
+	login, ok := c.loginCache[gitUserID]
+	return login, ok && login != ""
+}
+
+// cachedGitUserID returns a previously resolved user ID for the username
+func (c *Client) cachedGitUserID(githubID string) (string, bool) {
+	c.userCacheMu.RLock()
+	defer c.userCacheMu.RUnlock()
+	gitUserID, ok := c.userCache[strings.ToLower(githubID)]
+	return gitUserID, ok
+}
+
+// cacheGitUserID records a resolved user ID for the username
+func (c *Client) cacheGitUserID(githubID, gitUserID string) {
+	c.userCacheMu.Lock()
+	defer c.userCacheMu.Unlock()
+	if c.userCache == nil {
+		c.userCache = make(map[string]string)
+	}
+	c.userCache[strings.ToLower(githubID)] = gitUserID
+}
+
+// cacheGitHubLogin records the canonical login returned by GitHub for a user ID
+func (c *Client) cacheGitHubLogin(gitUserID, login string) {
+	c.userCacheMu.Lock()
+	defer c.userCacheMu.Unlock()
+	if c.loginCache == nil {
+		c.loginCache = make(map[string]string)
+	}
+	c.loginCache[gitUserID] = login
+}
+
+// fetchGitUserID resolves a GitHub username to a numeric user ID via the REST API
+func (c *Client) fetchGitUserID(ctx context.Context, githubID string) (string, error) {
+	respBody, err := c.doGitHubRequestCtx(ctx, http.MethodGet, "/users/"+url.PathEscape(githubID), nil)
+	if err != nil {
+		if isGitHubStatus(err, http.StatusNotFound) {
+			return "", fmt.Errorf("GitHub user '%s' not found", githubID)
+		}
+		return "", err
+	}
+
+	var user GitHubUserResponse
+	if err := json.Unmarshal(respBody, &user); err != nil {
+		return "", fmt.Errorf("failed to parse GitHub API response: %w", err)
+	}
+
+	// A redirect, e.g. for a renamed account, can resolve to another login
+	if user.Login != "" && !strings.EqualFold(user.Login, githubID) {
+		tflog.Info(ctx, "GitHub username resolved to a different login", map[string]interface{}{
+			"github_id": githubID,
+			"login":     user.Login,
+		})
+	}
+
+	gitUserID := fmt.Sprintf("%d", user.ID)
+	c.cacheGitHubLogin(gitUserID, user.Login)
+	return gitUserID, nil
+}
+
+// githubUserSearchResponse represents the response from GET /search/users
+type githubUserSearchResponse struct {
+	TotalCount int                  `json:"total_count"`
+	Items      []GitHubUserResponse `json:"items"`
+}
+
+// GetGitUserIDByEmail resolves an email address to a numeric GitHub user ID
+// using the user search API. Search requires an authenticated client and
+// only matches users whose email is public on their profile.
+func (c *Client) GetGitUserIDByEmail(ctx context.Context, email string) (string, error) {
+	if !c.HasGitHubAuth() {
+		return "", fmt.Errorf("resolving users by email requires GitHub authentication; set github_token (or GITHUB_TOKEN)")
+	}
+
+	query := url.Values{"q": {email + " in:email"}}
+	respBody, err := c.doGitHubRequestCtx(ctx, http.MethodGet, "/search/users?"+query.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+
+	var result githubUserSearchResponse
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return "", fmt.Errorf("failed to parse GitHub search response: %w", err)
+	}
+
+	switch {
+	case result.TotalCount == 0 || len(result.Items) == 0:
+		return "", fmt.Errorf("no GitHub user found with email '%s'; the email must be public on the user's GitHub profile", email)
+	case result.TotalCount > 1:
+		logins := make([]string, 0, len(result.Items))
+		for _, item := range result.Items {
+			logins = append(logins, item.Login)
+		}
+		return "", fmt.Errorf("email '%s' matches multiple GitHub users (%s); use github_id instead", email, strings.Join(logins, ", "))
+	}
+
+	user := result.Items[0]
+	gitUserID := fmt.Sprintf("%d", user.ID)
+	c.cacheGitUserID(user.Login, gitUserID)
+	c.cacheGitHubLogin(gitUserID, user.Login)
+	return gitUserID, nil
+}
+
+// githubTeamMembersPageSize is the number of team members requested per page
+const githubTeamMembersPageSize = 100
+
+// GetGitHubTeamMembers lists every member of a GitHub team, including members
+// of its child teams. It requires GitHub authentication with the read:org
+// scope on a token, or organization members read access on a GitHub App.
+func (c *Client) GetGitHubTeamMembers(ctx context.Context, org, teamSlug string) ([]GitHubUserResponse, error) {
+	if !c.HasGitHubAuth() {
+		return nil, fmt.Errorf("listing GitHub team members requires GitHub authentication; set github_token (with the read:org scope) or configure a GitHub App")
+	}
+
+	var members []GitHubUserResponse
+	for page := 1; ; page++ {
+		path := fmt.Sprintf("/orgs/%s/teams/%s/members?per_page=%d&page=%d", url.PathEscape(org), url.PathEscape(teamSlug), githubTeamMembersPageSize, page)
+		respBody, err := c.doGitHubRequestCtx(ctx, http.MethodGet, path, nil)
+		if err != nil {
+			if isGitHubStatus(err, http.StatusNotFound) {
+				return nil, fmt.Errorf("GitHub team '%s/%s' not found, or the token lacks the read:org scope", org, teamSlug)
+			}
+			return nil, err
+		}
+
+		var pageMembers []GitHubUserResponse
+		if err := json.Unmarshal(respBody, &pageMembers); err != nil {
+			return nil, fmt.Errorf("failed to parse GitHub API response: %w", err)
+		}
+
+		for _, member := range pageMembers {
+			gitUserID := fmt.Sprintf("%d", member.ID)
+			c.cacheGitUserID(member.Login, gitUserID)
+			c.cacheGitHubLogin(gitUserID, member.Login)
+		}
+		members = append(members, pageMembers...)
+
+		if len(pageMembers) < githubTeamMembersPageSize {
+			return members, nil
+		}
+	}
+}
+
+// GitHubEventsMaxAge is how far back the GitHub events API reports activity
+const GitHubEventsMaxAge = 90 * 24 * time.Hour
+
+// githubEventsMaxCount is the most events the GitHub events API returns for a
+// user across all pages, regardless of their age
+const githubEventsMaxCount = 300
+
+// maxGitHubEventsPages bounds how many events pages GetGitHubLastActivity follows
+const maxGitHubEventsPages = 10
+
+// githubEvent represents an entry of the GitHub events API
+type githubEvent struct {
+	Repo struct {
+		Name string `json:"name"`
+	} `json:"repo"`
+	Org *struct {
+		Login string `json:"login"`
+	} `json:"org"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// GetGitHubLastActivity returns the time of a user's most recent event in
+// the repositories of org, or the zero time when the user has no such event
+// since the given time. Pages are followed via the Link header until an org
+// event is found or the events reach back past since. GitHub only reports up
+// to 300 events from the last 90 days (GitHubEventsMaxAge), so when the
+// history of a very active user ends before since, inactivity can't be
+// established and an error is returned instead of the zero time. Only public
+// events are reported unless the token can see the user's private activity.
+func (c *Client) GetGitHubLastActivity(ctx context.Context, login, org string, since time.Time) (time.Time, error) {
+	basePath := fmt.Sprintf("/users/%s/events", url.PathEscape(login))
+	path := basePath + "?per_page=100"
+	prefix := strings.ToLower(org) + "/"
+
+	count := 0
+	var oldest time.Time
+	for page := 1; ; page++ {
+		respBody, header, err := c.doGitHubRequestWithHeaderCtx(ctx, http.MethodGet, path, nil)
+		if err != nil {
+			if isGitHubStatus(err, http.StatusNotFound) {
+				return time.Time{}, fmt.Errorf("GitHub user '%s' not found", login)
+			}
+			return time.Time{}, err
+		}
+
+		var events []githubEvent
+		if err := json.Unmarshal(respBody, &events); err != nil {
+			return time.Time{}, fmt.Errorf("failed to parse GitHub API response: %w", err)
+		}
+
+		// Events are returned newest first
+		for _, event := range events {
+			if (event.Org != nil && strings.EqualFold(event.Org.Login, org)) || strings.HasPrefix(strings.ToLower(event.Repo.Name), prefix) {
+				return event.CreatedAt, nil
+			}
+			oldest = event.CreatedAt
+		}
+		count += len(events)
+
+		if len(events) == 0 || oldest.Before(since) {
+			return time.Time{}, nil
+		}
+
+		next := nextPage(basePath, "", header)
+		if next == "" {
+			break
+		}
+		if page >= maxGitHubEventsPages {
+			return time.Time{}, fmt.Errorf("GitHub events of user '%s' did not end after %d pages", login, page)
+		}
+		path = next
+	}
+
+	// A history cut short by GitHub's event limit doesn't show whether the
+	// user was active in org before its oldest event
+	if count >= githubEventsMaxCount {
+		return time.Time{}, fmt.Errorf("GitHub only reports the latest %d events of user '%s', which reach back to %s and don't include activity in %s; their activity since %s can't be checked", githubEventsMaxCount, login, oldest.UTC().Format(time.RFC3339), org, since.UTC().Format(time.RFC3339))
+	}
+	return time.Time{}, nil
+}
+
+// graphQLRequest represents a GitHub GraphQL request body
+type graphQLRequest struct {
+	Query     string            `json:"query"`
+	Variables map[string]string `json:"variables"`
+}
+
+// graphQLUser represents a user node in a GitHub GraphQL response
+type graphQLUser struct {
+	DatabaseID int    `json:"databaseId"`
+	Login      string `json:"login"`
+}
+
+// graphQLUsersResponse represents the response to a batched user lookup
+type graphQLUsersResponse struct {
+	Data   map[string]*graphQLUser `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// GetGitUserIDs resolves many GitHub usernames to numeric user IDs, keyed by
+// the usernames as given. On github.com with a token it batches lookups via
+// the GraphQL API; otherwise it falls back to one REST call per username.
+// When some usernames cannot be resolved, the resolved subset is returned
+// together with an error naming the rest.
+func (c *Client) GetGitUserIDs(ctx context.Context, usernames []string) (map[string]string, error) {
+	resolved := make(map[string]string, len(usernames))
+	seen := make(map[string]bool, len(usernames))
+	var pending []string
+	for _, username := range usernames {
+		if seen[username] {
+			continue
+		}
+		seen[username] = true
+
+		if gitUserID, ok := c.cachedGitUserID(username); ok {
+			resolved[username] = gitUserID
+		} else {
+			pending = append(pending, username)
+		}
+	}
+
+	failed := make(map[string]error)
+
+	if c.isGitHubDotCom() && c.HasGitHubAuth() {
+		for start := 0; start < len(pending); start += graphQLBatchSize {
+			end := start + graphQLBatchSize
+			if end > len(pending) {
+				end = len(pending)
+			}
+			if err := c.resolveGraphQLBatch(ctx, pending[start:end], resolved, failed); err != nil {
+				return resolved, err
+			}
+		}
+	} else {
+		for _, username := range pending {
+			gitUserID, err := c.GetGitUserID(ctx, username)
+			if err != nil {
+				if ctx.Err() != nil {
+					return resolved, err
+				}
+				failed[username] = err
+				continue
+			}
+			resolved[username] = gitUserID
+		}
+	}
+
+	if len(failed) > 0 {
+		names := make([]string, 0, len(failed))
+		for username := range failed {
+			names = append(names, username)
+		}
+		sort.Strings(names)
+		return resolved, fmt.Errorf("could not resolve GitHub users: %s", strings.Join(names, ", "))
+	}
+
+	return resolved, nil
+}
+
+// resolveGraphQLBatch resolves a single batch of usernames with aliased
+// user(login:) queries, recording results in resolved and misses in failed
+func (c *Client) resolveGraphQLBatch(ctx context.Context, usernames []string, resolved map[string]string, failed map[string]error) error {
+	var params, fields []string
+	variables := make(map[string]string, len(usernames))
+	for i, username := range usernames {
+		params = append(params, fmt.Sprintf("$l%d: String!", i))
+		fields = append(fields, fmt.Sprintf("u%d: user(login: $l%d) { databaseId login }", i, i))
+		variables[fmt.Sprintf("l%d", i)] = username
+	}
+	query := fmt.Sprintf("query(%s) { %s }", strings.Join(params, ", "), strings.Join(fields, " "))
+
+	respBody, err := c.doGitHubRequestCtx(ctx, http.MethodPost, "/graphql", graphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return err
+	}
+
+	var result graphQLUsersResponse
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return fmt.Errorf("failed to parse GitHub GraphQL response: %w", err)
+	}
+	if result.Data == nil && len(result.Errors) > 0 {
+		return fmt.Errorf("GitHub GraphQL error: %s", result.Errors[0].Message)
+	}
+
+	for i, username := range usernames {
+		user := result.Data[fmt.Sprintf("u%d", i)]
+		if user == nil || user.DatabaseID == 0 {
+			failed[username] = fmt.Errorf("GitHub user '%s' not found", username)
+			continue
+		}
+		gitUserID := fmt.Sprintf("%d", user.DatabaseID)
+		resolved[username] = gitUserID
+		c.cacheGitUserID(username, gitUserID)
+		c.cacheGitHubLogin(gitUserID, user.Login)
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package client
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// installationTokenRefreshWindow is how long before expiry an installation
+// token is considered stale and re-minted
+const installationTokenRefreshWindow = 5 * time.Minute
+
+// githubApp holds GitHub App credentials and the current installation token
+type githubApp struct {
+	appID          int64
+	installationID int64
+	privateKey     *rsa.PrivateKey
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// installationTokenResponse represents the response from
+// POST /app/installations/{id}/access_tokens
+type installationTokenResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// SetGitHubApp configures the client to authenticate GitHub requests with
+// short-lived installation tokens minted for the given GitHub App. It takes
+// precedence over GitHubToken.
+func (c *Client) SetGitHubApp(appID, installationID int64, privateKeyPEM []byte) error {
+	key, err := parseRSAPrivateKey(privateKeyPEM)
+	if err != nil {
+		return err
+	}
+
+	c.githubApp = &githubApp{
+		appID:          appID,
+		installationID: installationID,
+		privateKey:     key,
+	}
+	return nil
+}
+
+// HasGitHubAuth reports whether GitHub requests are authenticated
+func (c *Client) HasGitHubAuth() bool {
+	return c.githubApp != nil || c.GitHubToken != ""
+}
+
+// githubAuthToken returns the bearer token to send on GitHub requests, if any
+func (c *Client) githubAuthToken(ctx context.Context) (string, error) {
+	if c.githubApp == nil {
+		return c.GitHubToken, nil
+	}
+	return c.githubApp.installationToken(ctx, c)
+}
+
+// installationToken returns a valid installation token, minting a new one
+// when none is cached or the cached one is close to expiry
+func (a *githubApp) installationToken(ctx context.Context, c *Client) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token != "" && time.Until(a.expiresAt) > installationTokenRefreshWindow {
+		return a.token, nil
+	}
+
+	jwt, err := a.signJWT(time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	tokenURL := fmt.Sprintf("%s/app/installations/%d/access_tokens", c.GitHubBaseURL, a.installationID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create GitHub App token request: %w", err)
+	}
+	c.setDefaultHeaders(req)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", c.UserAgent)
+	req.Header.Set("Authorization", "Bearer "+jwt)
+
+	resp, err := c.GitHubHTTPClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to request GitHub App installation token: %w", err)
+	}
+	respBody, err := c.readBody(resp)
+	if err != nil {
+		return "", fmt.Errorf("failed to read GitHub App installation token response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("failed to mint GitHub App installation token: %w", newGitHubAPIError(resp.StatusCode, respBody))
+	}
+
+	var tokenResp installationTokenResponse
+	if err := json.Unmarshal(respBody, &tokenResp); err != nil {
+		return "", fmt.Errorf("failed to parse GitHub App installation token response: %w", err)
+	}
+
+	a.token = tokenResp.Token
+	a.expiresAt = tokenResp.ExpiresAt
+	return a.token, nil
+}
+
+// signJWT creates the RS256-signed JWT used to authenticate as the GitHub App
+func (a *githubApp) signJWT(now time.Time) (string, error) {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`))
+
+	// Backdate issued-at to allow for clock drift; GitHub caps expiry at 10 minutes
+	claims, err := json.Marshal(map[string]int64{
+		"iat": now.Add(-60 * time.Second).Unix(),
+		"exp": now.Add(9 * time.Minute).Unix(),
+		"iss": a.appID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal GitHub App JWT claims: %w", err)
+	}
+	payload := base64.RawURLEncoding.EncodeToString(claims)
+
+	digest := sha256.Sum256([]byte(header + "." + payload))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, a.privateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign GitHub App JWT: %w", err)
+	}
+
+	return header + "." + payload + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// parseRSAPrivateKey parses a PEM-encoded PKCS#1 or PKCS#8 RSA private key
+func parseRSAPrivateKey(pemData []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return nil, errors.New("GitHub App private key is not valid PEM")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse GitHub App private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("GitHub App private key must be an RSA key")
+	}
+	return key, nil
+}
@@ -0,0 +1,430 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// secondaryRateLimited returns a handler answering the first request with a
+// GitHub secondary rate limit asking to wait retryAfter, and later ones with
+// the user octocat
+func secondaryRateLimited(retryAfter string, requests *atomic.Int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("Retry-After", retryAfter)
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message":"You have exceeded a secondary rate limit"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":583231,"login":"octocat"}`))
+	}
+}
+
+func TestGitHubSecondaryRateLimitDelayCappedAtMaxDelay(t *testing.T) {
+	var requests atomic.Int32
+	c := newTestClient(t, secondaryRateLimited("3600", &requests))
+	c.RetryConfig.MaxDelay = 10 * time.Millisecond
+
+	start := time.Now()
+	id, err := c.GetGitUserID(context.Background(), "octocat")
+	if err != nil {
+		t.Fatalf("GetGitUserID() error = %v", err)
+	}
+	if id != "583231" {
+		t.Errorf("GetGitUserID() = %q, want 583231", id)
+	}
+	if requests.Load() != 2 {
+		t.Errorf("got %d requests, want 2", requests.Load())
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("GetGitUserID() took %s, want the Retry-After wait capped at MaxDelay", elapsed)
+	}
+}
+
+func TestGitHubSecondaryRateLimitBeyondMaxElapsedFails(t *testing.T) {
+	var requests atomic.Int32
+	c := newTestClient(t, secondaryRateLimited("60", &requests))
+	c.RetryConfig.MaxDelay = time.Hour
+	c.RetryConfig.MaxElapsed = 100 * time.Millisecond
+
+	start := time.Now()
+	_, err := c.GetGitUserID(context.Background(), "octocat")
+	if err == nil {
+		t.Fatal("GetGitUserID() error = nil, want retry time budget error")
+	}
+	if !strings.Contains(err.Error(), "retry time budget") || !strings.Contains(err.Error(), "secondary rate limit") {
+		t.Errorf("GetGitUserID() error = %q, want the budget and the rate limit message", err)
+	}
+	if requests.Load() != 1 {
+		t.Errorf("got %d requests, want 1", requests.Load())
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("GetGitUserID() took %s, want it to fail without waiting", elapsed)
+	}
+}
+
+func TestGitHubPrimaryRateLimitExhausted(t *testing.T) {
+	reset := time.Now().Add(time.Hour).Unix()
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", fmt.Sprint(reset))
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message":"API rate limit exceeded"}`))
+	}))
+
+	_, err := c.GetGitUserID(context.Background(), "octocat")
+	if err == nil || !strings.Contains(err.Error(), "set github_token") {
+		t.Errorf("GetGitUserID() error = %v, want a hint to set github_token", err)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"", 0, false},
+		{"0", 0, true},
+		{"30", 30 * time.Second, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+		{time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat), 0, true},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("parseRetryAfter(%q) = %s, %v, want %s, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+// eventsPage renders n GitHub events in repo, one minute apart starting at
+// newest, newest first
+func eventsPage(repo string, n int, newest time.Time) string {
+	events := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		created := newest.Add(-time.Duration(i) * time.Minute).UTC().Format(time.RFC3339)
+		events = append(events, fmt.Sprintf(`{"repo":{"name":%q},"created_at":%q}`, repo, created))
+	}
+	return "[" + strings.Join(events, ",") + "]"
+}
+
+// eventsServer serves pages of GitHub events for octocat, linking each page
+// to the next
+func eventsServer(t *testing.T, pages []string, requests *atomic.Int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/users/octocat/events" {
+			t.Errorf("request to %s, want /users/octocat/events", r.URL.Path)
+		}
+		requests.Add(1)
+
+		page := 1
+		if p := r.URL.Query().Get("page"); p != "" {
+			fmt.Sscan(p, &page)
+		}
+		if page < len(pages) {
+			w.Header().Set("Link", fmt.Sprintf(`<https://api.github.com/user/1/events?per_page=100&page=%d>; rel="next"`, page+1))
+		}
+		_, _ = w.Write([]byte(pages[page-1]))
+	}
+}
+
+func TestGetGitHubLastActivityFollowsPages(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	orgEvent := now.Add(-3 * time.Hour)
+
+	var requests atomic.Int32
+	c := newTestClient(t, eventsServer(t, []string{
+		eventsPage("other/repo", 100, now),
+		eventsPage("acme/api", 1, orgEvent),
+	}, &requests))
+
+	got, err := c.GetGitHubLastActivity(context.Background(), "octocat", "acme", now.Add(-30*24*time.Hour))
+	if err != nil {
+		t.Fatalf("GetGitHubLastActivity() error = %v", err)
+	}
+	if !got.Equal(orgEvent) {
+		t.Errorf("GetGitHubLastActivity() = %s, want %s from the second page", got, orgEvent)
+	}
+	if requests.Load() != 2 {
+		t.Errorf("got %d requests, want 2", requests.Load())
+	}
+}
+
+func TestGetGitHubLastActivityStopsAtCutoff(t *testing.T) {
+	now := time.Now()
+
+	var requests atomic.Int32
+	c := newTestClient(t, eventsServer(t, []string{
+		eventsPage("other/repo", 100, now),
+		eventsPage("other/repo", 100, now.Add(-40*24*time.Hour)),
+		eventsPage("acme/api", 100, now.Add(-50*24*time.Hour)),
+	}, &requests))
+
+	got, err := c.GetGitHubLastActivity(context.Background(), "octocat", "acme", now.Add(-30*24*time.Hour))
+	if err != nil {
+		t.Fatalf("GetGitHubLastActivity() error = %v", err)
+	}
+	if !got.IsZero() {
+		t.Errorf("GetGitHubLastActivity() = %s, want the zero time", got)
+	}
+	if requests.Load() != 2 {
+		t.Errorf("got %d requests, want pagination to stop once events predate the cutoff", requests.Load())
+	}
+}
+
+func TestGetGitHubLastActivityFailsClosedOnTruncatedHistory(t *testing.T) {
+	now := time.Now()
+
+	var requests atomic.Int32
+	c := newTestClient(t, eventsServer(t, []string{
+		eventsPage("other/repo", 100, now),
+		eventsPage("other/repo", 100, now.Add(-2*time.Hour)),
+		eventsPage("other/repo", 100, now.Add(-4*time.Hour)),
+	}, &requests))
+
+	_, err := c.GetGitHubLastActivity(context.Background(), "octocat", "acme", now.Add(-30*24*time.Hour))
+	if err == nil {
+		t.Fatal("GetGitHubLastActivity() error = nil, want an error when the history doesn't reach the cutoff")
+	}
+	if requests.Load() != 3 {
+		t.Errorf("got %d requests, want 3", requests.Load())
+	}
+}
+
+func TestGetGitHubLastActivityShortHistory(t *testing.T) {
+	now := time.Now()
+
+	var requests atomic.Int32
+	c := newTestClient(t, eventsServer(t, []string{
+		eventsPage("other/repo", 5, now),
+	}, &requests))
+
+	got, err := c.GetGitHubLastActivity(context.Background(), "octocat", "acme", now.Add(-30*24*time.Hour))
+	if err != nil {
+		t.Fatalf("GetGitHubLastActivity() error = %v", err)
+	}
+	if !got.IsZero() {
+		t.Errorf("GetGitHubLastActivity() = %s, want the zero time", got)
+	}
+}
+
+// scopesServer serves GET /user with the given X-OAuth-Scopes header, or
+// without one when scopes is nil
+func scopesServer(scopes []string, requests *atomic.Int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if scopes != nil {
+			w.Header().Set("X-OAuth-Scopes", strings.Join(scopes, ", "))
+		}
+		_, _ = w.Write([]byte(`{"id":1,"login":"octocat"}`))
+	}
+}
+
+func TestMissingGitHubTokenScopes(t *testing.T) {
+	var requests atomic.Int32
+	c := newTestClient(t, scopesServer([]string{"repo", "admin:org"}, &requests))
+	c.GitHubToken = "ghp_test"
+	c.ValidateGitHubScopes = true
+
+	ctx := context.Background()
+	missing, err := c.MissingGitHubTokenScopes(ctx, "read:org")
+	if err != nil || len(missing) != 0 {
+		t.Errorf("MissingGitHubTokenScopes(read:org) = %v, %v, want none missing through admin:org", missing, err)
+	}
+	missing, err = c.MissingGitHubTokenScopes(ctx, "read:org", "user:email")
+	if err != nil || len(missing) != 1 || missing[0] != "user:email" {
+		t.Errorf("MissingGitHubTokenScopes(read:org, user:email) = %v, %v, want [user:email]", missing, err)
+	}
+	if requests.Load() != 1 {
+		t.Errorf("got %d requests, want the scopes to be read once", requests.Load())
+	}
+}
+
+func TestMissingGitHubTokenScopesSkipped(t *testing.T) {
+	tests := []struct {
+		name     string
+		validate bool
+		token    string
+		scopes   []string
+		wantReqs int32
+	}{
+		{name: "validation disabled", validate: false, token: "ghp_test", scopes: []string{}, wantReqs: 0},
+		{name: "no token", validate: true, token: "", scopes: []string{}, wantReqs: 0},
+		{name: "fine-grained token", validate: true, token: "github_pat_test", scopes: nil, wantReqs: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			c := newTestClient(t, scopesServer(tt.scopes, &requests))
+			c.GitHubToken = tt.token
+			c.ValidateGitHubScopes = tt.validate
+
+			missing, err := c.MissingGitHubTokenScopes(context.Background(), "read:org")
+			if err != nil || missing != nil {
+				t.Errorf("MissingGitHubTokenScopes() = %v, %v, want nil", missing, err)
+			}
+			if requests.Load() != tt.wantReqs {
+				t.Errorf("got %d requests, want %d", requests.Load(), tt.wantReqs)
+			}
+		})
+	}
+}
+
+func TestGetGitUserIDCachesLookups(t *testing.T) {
+	var requests atomic.Int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(`{"id":583231,"login":"octocat"}`))
+	}))
+
+	ctx := context.Background()
+	for _, username := range []string{"octocat", "OctoCat"} {
+		id, err := c.GetGitUserID(ctx, username)
+		if err != nil {
+			t.Fatalf("GetGitUserID(%q) error = %v", username, err)
+		}
+		if id != "583231" {
+			t.Errorf("GetGitUserID(%q) = %q, want 583231", username, id)
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("GitHub requests = %d, want 1", got)
+	}
+
+	c.InvalidateUserCache()
+	if _, err := c.GetGitUserID(ctx, "octocat"); err != nil {
+		t.Fatalf("GetGitUserID() after InvalidateUserCache error = %v", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("GitHub requests after InvalidateUserCache = %d, want 2", got)
+	}
+}
+
+func TestGetGitUserIDEscapesUsername(t *testing.T) {
+	var path, query string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, query = r.URL.EscapedPath(), r.URL.RawQuery
+		_, _ = w.Write([]byte(`{"id":583231,"login":"octocat"}`))
+	}))
+
+	// Import IDs skip the username validator, so the lookup must not be
+	// steered to another endpoint
+	if _, err := c.GetGitUserID(context.Background(), "octocat/repos?per_page=1#top"); err != nil {
+		t.Fatalf("GetGitUserID() error = %v", err)
+	}
+	if want := "/users/octocat%2Frepos%3Fper_page=1%23top"; path != want {
+		t.Errorf("request path = %q, want %q", path, want)
+	}
+	if query != "" {
+		t.Errorf("request query = %q, want none", query)
+	}
+}
+
+func TestGetGitUserIDFollowsRenameRedirect(t *testing.T) {
+	var requests atomic.Int32
+	var redirectedAuth atomic.Value
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch r.URL.Path {
+		case "/users/oldname":
+			http.Redirect(w, r, "/user/583231", http.StatusMovedPermanently)
+		case "/user/583231":
+			redirectedAuth.Store(r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(`{"id":583231,"login":"newname"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	c.GitHubToken = "ghp_test"
+
+	ctx := context.Background()
+	id, err := c.GetGitUserID(ctx, "oldname")
+	if err != nil {
+		t.Fatalf("GetGitUserID() error = %v", err)
+	}
+	if id != "583231" {
+		t.Errorf("GetGitUserID() = %q, want 583231", id)
+	}
+	if got, _ := redirectedAuth.Load().(string); got != "Bearer ghp_test" {
+		t.Errorf("Authorization after same-host redirect = %q, want the token kept", got)
+	}
+
+	// The login the redirect resolved to is recorded without another request
+	login, err := c.GetGitHubLogin(ctx, "583231")
+	if err != nil {
+		t.Fatalf("GetGitHubLogin() error = %v", err)
+	}
+	if login != "newname" {
+		t.Errorf("GetGitHubLogin() = %q, want newname", login)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("GitHub requests = %d, want 2", got)
+	}
+}
+
+func TestCheckGitHubRedirect(t *testing.T) {
+	original, _ := http.NewRequest(http.MethodGet, "https://api.github.com/users/oldname", nil)
+	original.Header.Set("Authorization", "Bearer ghp_test")
+
+	sameHost, _ := http.NewRequest(http.MethodGet, "https://api.github.com/user/583231", nil)
+	if err := checkGitHubRedirect(sameHost, []*http.Request{original}); err != nil {
+		t.Fatalf("checkGitHubRedirect() same host error = %v", err)
+	}
+	if got := sameHost.Header.Get("Authorization"); got != "Bearer ghp_test" {
+		t.Errorf("same-host Authorization = %q, want the token kept", got)
+	}
+
+	otherHost, _ := http.NewRequest(http.MethodGet, "https://example.com/user/583231", nil)
+	otherHost.Header.Set("Authorization", "Bearer ghp_test")
+	if err := checkGitHubRedirect(otherHost, []*http.Request{original}); err != nil {
+		t.Fatalf("checkGitHubRedirect() other host error = %v", err)
+	}
+	if got := otherHost.Header.Get("Authorization"); got != "" {
+		t.Errorf("cross-host Authorization = %q, want it dropped", got)
+	}
+
+	via := make([]*http.Request, maxGitHubRedirects)
+	for i := range via {
+		via[i] = original
+	}
+	if err := checkGitHubRedirect(sameHost, via); err == nil {
+		t.Errorf("checkGitHubRedirect() after %d redirects error = nil, want the redirect cap", maxGitHubRedirects)
+	}
+}
+
+func TestGitHubAPIErrorMessage(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message":"Bad credentials","documentation_url":"https://docs.github.com/rest"}`))
+	}))
+	c.GitHubToken = "ghp_invalid"
+
+	_, err := c.GetGitUserID(context.Background(), "octocat")
+	var ghErr *GitHubAPIError
+	if !errors.As(err, &ghErr) {
+		t.Fatalf("GetGitUserID() error = %v, want a *GitHubAPIError", err)
+	}
+	if ghErr.StatusCode != http.StatusUnauthorized || ghErr.Message != "Bad credentials" || ghErr.DocumentationURL != "https://docs.github.com/rest" {
+		t.Errorf("GitHubAPIError = %+v, want status 401 with GitHub's message and documentation URL", ghErr)
+	}
+	if want := "GitHub API error (status 401): Bad credentials (see https://docs.github.com/rest)"; !strings.Contains(err.Error(), want) {
+		t.Errorf("GetGitUserID() error = %q, want it to contain %q", err, want)
+	}
+}
+
+func TestGitHubAPIErrorWithoutJSONBody(t *testing.T) {
+	err := newGitHubAPIError(http.StatusBadGateway, []byte("<html>Bad Gateway</html>"))
+	if got, want := err.Error(), "GitHub API error (status 502)"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
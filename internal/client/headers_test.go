@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+func TestDefaultHeadersOnEveryRequest(t *testing.T) {
+	var mu sync.Mutex
+	seen := make(map[string]http.Header)
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen[r.URL.Path] = r.Header.Clone()
+		mu.Unlock()
+		if r.URL.Path == "/users/octocat" {
+			_, _ = w.Write([]byte(`{"id":583231,"login":"octocat"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"users":[]}`))
+	}))
+	c.DefaultHeaders = map[string]string{
+		"X-Corp-Env":             "prod",
+		"x-coderabbitai-api-key": "overridden",
+	}
+
+	ctx := context.Background()
+	if _, err := c.GetSeats(ctx); err != nil {
+		t.Fatalf("GetSeats() error = %v", err)
+	}
+	if _, err := c.GetGitUserID(ctx, "octocat"); err != nil {
+		t.Fatalf("GetGitUserID() error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, path := range []string{"/v1/seats/", "/users/octocat"} {
+		header, ok := seen[path]
+		if !ok {
+			t.Errorf("no request to %s", path)
+			continue
+		}
+		if got := header.Get("X-Corp-Env"); got != "prod" {
+			t.Errorf("%s X-Corp-Env = %q, want prod", path, got)
+		}
+	}
+	if got := seen["/v1/seats/"].Get("x-coderabbitai-api-key"); got != "test-api-key" {
+		t.Errorf("x-coderabbitai-api-key = %q, want the API key rather than the default header", got)
+	}
+}
+
+func TestIsReservedHeader(t *testing.T) {
+	for name, want := range map[string]bool{
+		"x-coderabbitai-api-key": true,
+		"X-CodeRabbitAI-API-Key": true,
+		"authorization":          true,
+		"Host":                   true,
+		"Idempotency-Key":        true,
+		"X-Corp-Env":             false,
+	} {
+		if got := IsReservedHeader(name); got != want {
+			t.Errorf("IsReservedHeader(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+)
+
+// idempotencyKeyContextKey is the context key carrying a request's idempotency key
+type idempotencyKeyContextKey struct{}
+
+// withIdempotencyKey returns a context whose CodeRabbit requests, including
+// all of their retries, send key as the Idempotency-Key header
+func withIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+// idempotencyKey returns the idempotency key of ctx, or "" if it has none
+func idempotencyKey(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyContextKey{}).(string)
+	return key
+}
+
+// newIdempotencyKey returns a random version 4 UUID
+func newIdempotencyKey() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("failed to generate idempotency key: %w", err)
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
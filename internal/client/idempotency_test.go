@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+func TestAssignSeatReusesIdempotencyKeyAcrossRetries(t *testing.T) {
+	var mu sync.Mutex
+	var keys []string
+	responses := []struct {
+		status int
+		body   string
+	}{
+		{http.StatusServiceUnavailable, `{"errors":[{"message":"unavailable"}]}`},
+		{http.StatusOK, `{"success":false,"message":"try again"}`},
+		{http.StatusOK, `{"success":true}`},
+	}
+
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		resp := responses[len(keys)-1]
+		w.WriteHeader(resp.status)
+		_, _ = w.Write([]byte(resp.body))
+	}))
+
+	if err := c.AssignSeat(context.Background(), "12345"); err != nil {
+		t.Fatalf("AssignSeat() error = %v", err)
+	}
+
+	if len(keys) != len(responses) {
+		t.Fatalf("got %d requests, want %d", len(keys), len(responses))
+	}
+	if keys[0] == "" {
+		t.Fatal("request carried no Idempotency-Key header")
+	}
+	for i, key := range keys {
+		if key != keys[0] {
+			t.Errorf("request %d Idempotency-Key = %q, want %q", i, key, keys[0])
+		}
+	}
+}
+
+func TestSeatMutationsUseDistinctIdempotencyKeys(t *testing.T) {
+	var mu sync.Mutex
+	seen := make(map[string]bool)
+
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen[r.Header.Get("Idempotency-Key")] = true
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"success":true}`))
+	}))
+
+	ctx := context.Background()
+	if err := c.AssignSeat(ctx, "12345"); err != nil {
+		t.Fatalf("AssignSeat() error = %v", err)
+	}
+	if err := c.UnassignSeat(ctx, "12345"); err != nil {
+		t.Fatalf("UnassignSeat() error = %v", err)
+	}
+
+	if len(seen) != 2 {
+		t.Errorf("got %d distinct Idempotency-Key values, want 2", len(seen))
+	}
+}
+
+func TestNewIdempotencyKeyIsUUIDv4(t *testing.T) {
+	key, err := newIdempotencyKey()
+	if err != nil {
+		t.Fatalf("newIdempotencyKey() error = %v", err)
+	}
+	if len(key) != 36 || key[14] != '4' {
+		t.Errorf("newIdempotencyKey() = %q, want a version 4 UUID", key)
+	}
+}
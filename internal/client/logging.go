@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// redactedHeaders lists request headers whose values are never logged
+var redactedHeaders = []string{"x-coderabbitai-api-key", "Authorization"}
+
+// loggableHeaders returns the request headers with credentials redacted
+func loggableHeaders(h http.Header) map[string]string {
+	headers := make(map[string]string, len(h))
+	for name, values := range h {
+		headers[name] = strings.Join(values, ", ")
+	}
+	for _, name := range redactedHeaders {
+		if _, ok := headers[http.CanonicalHeaderKey(name)]; ok {
+			headers[http.CanonicalHeaderKey(name)] = "[REDACTED]"
+		}
+	}
+	return headers
+}
+
+// logAttempt logs a single HTTP request attempt at debug level and reports it
+// to the RequestObserver, if any
+func (c *Client) logAttempt(ctx context.Context, req *http.Request, attempt, statusCode int, latency time.Duration, err error) {
+	if c.RequestObserver != nil {
+		c.RequestObserver(req.Method, req.URL.Path, statusCode, attempt, latency, err)
+	}
+
+	fields := map[string]interface{}{
+		"method":      req.Method,
+		"path":        req.URL.Path,
+		"attempt":     attempt,
+		"max_retries": c.RetryConfig.MaxRetries,
+		"latency_ms":  latency.Milliseconds(),
+		"headers":     loggableHeaders(req.Header),
+	}
+	if statusCode != 0 {
+		fields["status_code"] = statusCode
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+	}
+
+	tflog.Debug(ctx, "HTTP request attempt", fields)
+}
+
+// logDryRun logs a seat change that DryRun skipped
+func logDryRun(ctx context.Context, path, gitUserID string) {
+	tflog.Info(ctx, "Dry run: skipping seat change", map[string]interface{}{
+		"path":        path,
+		"git_user_id": gitUserID,
+	})
+}
@@ -0,0 +1,124 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// oidcTokenRefreshWindow is how long before expiry an exchanged CodeRabbit
+// token is considered stale and exchanged again
+const oidcTokenRefreshWindow = 1 * time.Minute
+
+// oidcDefaultTokenLifetime is how long an exchanged token is reused when the
+// token endpoint does not report its lifetime
+const oidcDefaultTokenLifetime = 5 * time.Minute
+
+// RFC 8693 token exchange parameters
+const (
+	oidcGrantTypeTokenExchange = "urn:ietf:params:oauth:grant-type:token-exchange"
+	oidcTokenTypeJWT           = "urn:ietf:params:oauth:token-type:jwt"
+)
+
+// oidcAuth holds the settings for exchanging a workload identity token for a
+// short-lived CodeRabbit token, and the current CodeRabbit token
+type oidcAuth struct {
+	tokenURL string
+
+	// subjectToken returns the current OIDC token to exchange. It is called
+	// on every exchange so that rotated tokens are picked up.
+	subjectToken func() (string, error)
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// oidcTokenResponse represents an RFC 8693 token exchange response
+type oidcTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// SetOIDC configures the client to authenticate CodeRabbit requests with
+// short-lived tokens obtained by exchanging the OIDC token returned by
+// subjectToken at tokenURL. It takes precedence over APIKey.
+func (c *Client) SetOIDC(tokenURL string, subjectToken func() (string, error)) {
+	c.oidc = &oidcAuth{
+		tokenURL:     tokenURL,
+		subjectToken: subjectToken,
+	}
+}
+
+// apiCredential returns the value to send in the x-coderabbitai-api-key header
+func (c *Client) apiCredential(ctx context.Context) (string, error) {
+	if c.oidc == nil {
+		return c.APIKey, nil
+	}
+	return c.oidc.accessToken(ctx, c)
+}
+
+// accessToken returns a valid CodeRabbit token, exchanging the OIDC token
+// for a new one when none is cached or the cached one is close to expiry
+func (o *oidcAuth) accessToken(ctx context.Context, c *Client) (string, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.token != "" && time.Until(o.expiresAt) > oidcTokenRefreshWindow {
+		return o.token, nil
+	}
+
+	subjectToken, err := o.subjectToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to read OIDC token: %w", err)
+	}
+
+	form := url.Values{
+		"grant_type":         {oidcGrantTypeTokenExchange},
+		"subject_token":      {subjectToken},
+		"subject_token_type": {oidcTokenTypeJWT},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create OIDC token exchange request: %w", err)
+	}
+	c.setDefaultHeaders(req)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.UserAgent)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to exchange OIDC token: %w", err)
+	}
+	respBody, err := c.readBody(resp)
+	if err != nil {
+		return "", fmt.Errorf("failed to read OIDC token exchange response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("failed to exchange OIDC token: %w", newAPIError(resp.StatusCode, respBody, requestID(resp.Header)))
+	}
+
+	var tokenResp oidcTokenResponse
+	if err := json.Unmarshal(respBody, &tokenResp); err != nil {
+		return "", fmt.Errorf("failed to parse OIDC token exchange response: %w", err)
+	}
+	if tokenResp.AccessToken == "" {
+		return "", fmt.Errorf("OIDC token exchange response has no access_token")
+	}
+
+	lifetime := oidcDefaultTokenLifetime
+	if tokenResp.ExpiresIn > 0 {
+		lifetime = time.Duration(tokenResp.ExpiresIn) * time.Second
+	}
+
+	o.token = tokenResp.AccessToken
+	o.expiresAt = time.Now().Add(lifetime)
+	return o.token, nil
+}
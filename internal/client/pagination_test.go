@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+// seatIDs returns the git_user_ids of a seats response, in order
+func seatIDs(seats *SeatsResponse) []string {
+	ids := make([]string, 0, len(seats.Users))
+	for _, user := range seats.Users {
+		ids = append(ids, user.GitUserID)
+	}
+	return ids
+}
+
+func TestGetSeatsFollowsCursor(t *testing.T) {
+	var requests atomic.Int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			_, _ = w.Write([]byte(`{"users":[{"git_user_id":"1","seat_assigned":true}],"next_cursor":"page 2"}`))
+		case "page 2":
+			_, _ = w.Write([]byte(`{"users":[{"git_user_id":"2","seat_assigned":true}]}`))
+		default:
+			t.Errorf("unexpected cursor %q", r.URL.Query().Get("cursor"))
+		}
+	}))
+
+	ctx := context.Background()
+	seats, err := c.GetSeats(ctx)
+	if err != nil {
+		t.Fatalf("GetSeats() error = %v", err)
+	}
+	if got := seatIDs(seats); !reflect.DeepEqual(got, []string{"1", "2"}) {
+		t.Errorf("GetSeats() users = %v, want [1 2]", got)
+	}
+	if ok, _ := c.HasSeat(ctx, "2"); !ok {
+		t.Error("HasSeat(2) = false, want the seat from the second page")
+	}
+
+	// The assembled response is cached as a whole
+	if got := requests.Load(); got != 2 {
+		t.Errorf("seats requests = %d, want 2", got)
+	}
+}
+
+func TestGetSeatsFollowsLinkHeader(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			_, _ = w.Write([]byte(`{"users":[{"git_user_id":"2","seat_assigned":true}]}`))
+			return
+		}
+		w.Header().Set("Link", `<https://api.coderabbit.ai/v1/seats/?page=2>; rel="next", <https://api.coderabbit.ai/v1/seats/?page=2>; rel="last"`)
+		_, _ = w.Write([]byte(`{"users":[{"git_user_id":"1","seat_assigned":true}]}`))
+	}))
+
+	seats, err := c.GetSeats(context.Background())
+	if err != nil {
+		t.Fatalf("GetSeats() error = %v", err)
+	}
+	if got := seatIDs(seats); !reflect.DeepEqual(got, []string{"1", "2"}) {
+		t.Errorf("GetSeats() users = %v, want [1 2]", got)
+	}
+}
+
+func TestGetSeatsPaginationLoop(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"users":[{"git_user_id":"1","seat_assigned":true}],"next_cursor":"again"}`))
+	}))
+
+	if _, err := c.GetSeats(context.Background()); err == nil {
+		t.Error("GetSeats() error = nil, want an error for a cursor that repeats")
+	}
+}
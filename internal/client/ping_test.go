@@ -0,0 +1,45 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestPing(t *testing.T) {
+	tests := []struct {
+		name             string
+		status           int
+		wantErr          bool
+		wantUnauthorized bool
+	}{
+		{name: "ok", status: http.StatusOK},
+		{name: "organization without seats", status: http.StatusNotFound},
+		{name: "invalid key", status: http.StatusUnauthorized, wantErr: true, wantUnauthorized: true},
+		{name: "forbidden key", status: http.StatusForbidden, wantErr: true, wantUnauthorized: true},
+		{name: "bad request", status: http.StatusBadRequest, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v1/seats/" || r.URL.Query().Get("limit") != "1" {
+					t.Errorf("request to %s, want /v1/seats/?limit=1", r.URL)
+				}
+				if r.Header.Get("x-coderabbitai-api-key") != "test-api-key" {
+					t.Errorf("request without the API key header")
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(`{"users":[]}`))
+			}))
+
+			err := c.Ping(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Ping() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if IsUnauthorized(err) != tt.wantUnauthorized {
+				t.Errorf("IsUnauthorized(%v) = %v, want %v", err, !tt.wantUnauthorized, tt.wantUnauthorized)
+			}
+		})
+	}
+}
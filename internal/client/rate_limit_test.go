@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRequestsPerSecondThrottlesRequests(t *testing.T) {
+	const requests = 20
+	const rps = 100
+
+	var served atomic.Int32
+	c := newTestClient(t, countingHandler(http.StatusOK, &served))
+	c.DisableCache = true
+	c.RetryConfig.RequestsPerSecond = rps
+
+	// Requests from concurrent callers share the client's limiter
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := c.Ping(context.Background()); err != nil {
+				t.Errorf("Ping() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	if served.Load() != requests {
+		t.Errorf("got %d requests, want %d", served.Load(), requests)
+	}
+	// With a burst of one, the first request is immediate and every further
+	// one waits 1/rps
+	if want := time.Duration(requests-1) * time.Second / rps; elapsed < want {
+		t.Errorf("%d requests took %s, want at least %s at %d requests per second", requests, elapsed, want, rps)
+	}
+}
+
+func TestRequestsPerSecondZeroIsUnlimited(t *testing.T) {
+	var served atomic.Int32
+	c := newTestClient(t, countingHandler(http.StatusOK, &served))
+
+	if c.rateLimiter() != nil {
+		t.Fatal("rateLimiter() != nil, want no limiter when RequestsPerSecond is 0")
+	}
+}
+
+func TestRequestsPerSecondCancelledWait(t *testing.T) {
+	var served atomic.Int32
+	c := newTestClient(t, countingHandler(http.StatusOK, &served))
+	c.RetryConfig.RequestsPerSecond = 0.01
+
+	if err := c.Ping(context.Background()); err != nil {
+		t.Fatalf("Ping() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := c.Ping(ctx); err == nil {
+		t.Fatal("Ping() error = nil, want the rate limited wait to be cancelled")
+	}
+	if served.Load() != 1 {
+		t.Errorf("got %d requests, want 1", served.Load())
+	}
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Repository represents a repository managed by CodeRabbit
+type Repository struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	FullName string `json:"full_name"`
+
+	// Enabled is whether CodeRabbit reviews the repository
+	Enabled bool `json:"enabled"`
+}
+
+// RepositoriesResponse represents the response from GET /repositories/
+type RepositoriesResponse struct {
+	Repositories []Repository `json:"repositories"`
+
+	// NextCursor is the cursor of the next page of a paginated response
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// repositoriesCacheEntry is a cached repositories response and the time it was fetched
+type repositoriesCacheEntry struct {
+	repositories *RepositoriesResponse
+	fetchedAt    time.Time
+}
+
+// maxRepositoriesPages bounds how many repositories pages fetchRepositories follows
+const maxRepositoriesPages = 1000
+
+// cachedRepositories returns the cached repositories response for key if it
+// can be reused. Callers must hold repositoriesCacheMu.
+func (c *Client) cachedRepositories(key string) (*RepositoriesResponse, bool) {
+	if c.DisableCache {
+		return nil, false
+	}
+
+	entry, ok := c.repositoriesCache[key]
+	if !ok {
+		return nil, false
+	}
+	if c.CacheTTL > 0 && c.clock().Sub(entry.fetchedAt) >= c.CacheTTL {
+		return nil, false
+	}
+	return entry.repositories, true
+}
+
+// GetRepositories retrieves all repositories managed by CodeRabbit (cached per
+// API key for CacheTTL)
+func (c *Client) GetRepositories(ctx context.Context) (*RepositoriesResponse, error) {
+	key := c.seatsCacheKey(c.APIKey)
+
+	// Check cache first with read lock
+	c.repositoriesCacheMu.RLock()
+	if cached, ok := c.cachedRepositories(key); ok {
+		c.repositoriesCacheMu.RUnlock()
+		return cached, nil
+	}
+	c.repositoriesCacheMu.RUnlock()
+
+	// Fetch from API with write lock
+	c.repositoriesCacheMu.Lock()
+	defer c.repositoriesCacheMu.Unlock()
+
+	// Double-check after acquiring write lock
+	if cached, ok := c.cachedRepositories(key); ok {
+		return cached, nil
+	}
+
+	repositories, err := c.fetchRepositories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.DisableCache {
+		return repositories, nil
+	}
+
+	if c.repositoriesCache == nil {
+		c.repositoriesCache = make(map[string]repositoriesCacheEntry)
+	}
+	c.repositoriesCache[key] = repositoriesCacheEntry{repositories: repositories, fetchedAt: c.clock()}
+	return repositories, nil
+}
+
+// fetchRepositories retrieves every page of repositories, following pages the
+// same way as fetchSeats
+func (c *Client) fetchRepositories(ctx context.Context) (*RepositoriesResponse, error) {
+	var repositories RepositoriesResponse
+	path := "/repositories/"
+	seen := make(map[string]bool)
+
+	for page := 1; ; page++ {
+		respBody, header, err := c.doRequestWithHeaderCtx(ctx, http.MethodGet, path, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var pageResp RepositoriesResponse
+		if err := json.Unmarshal(respBody, &pageResp); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+
+		repositories.Repositories = append(repositories.Repositories, pageResp.Repositories...)
+
+		next := nextPage("/repositories/", pageResp.NextCursor, header)
+		if next == "" {
+			return &repositories, nil
+		}
+		if seen[next] || page >= maxRepositoriesPages {
+			return nil, fmt.Errorf("repositories pagination did not terminate after %d pages", page)
+		}
+		seen[next] = true
+		path = next
+	}
+}
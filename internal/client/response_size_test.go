@@ -0,0 +1,41 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestMaxResponseBytes(t *testing.T) {
+	// Both responses are padded past the limit with whitespace, which is
+	// still valid JSON
+	padding := strings.Repeat(" ", 1024)
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/users/") {
+			_, _ = w.Write([]byte(`{"id":583231,"login":"octocat"}` + padding))
+			return
+		}
+		_, _ = w.Write([]byte(`{"users":[]}` + padding))
+	}))
+	c.MaxResponseBytes = 512
+
+	ctx := context.Background()
+	if _, err := c.GetSeats(ctx); !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("GetSeats() error = %v, want ErrResponseTooLarge", err)
+	}
+	if _, err := c.GetGitUserID(ctx, "octocat"); !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("GetGitUserID() error = %v, want ErrResponseTooLarge", err)
+	}
+
+	// Responses within the limit are read as usual
+	c.MaxResponseBytes = 2048
+	c.InvalidateUserCache()
+	if _, err := c.GetSeats(ctx); err != nil {
+		t.Errorf("GetSeats() within the limit error = %v", err)
+	}
+	if _, err := c.GetGitUserID(ctx, "octocat"); err != nil {
+		t.Errorf("GetGitUserID() within the limit error = %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMaxElapsedStopsRetriesEarly(t *testing.T) {
+	var requests atomic.Int32
+	c := newTestClient(t, countingHandler(http.StatusServiceUnavailable, &requests))
+	c.RetryConfig.MaxRetries = 10
+	c.RetryConfig.BaseDelay = 20 * time.Millisecond
+	c.RetryConfig.MaxDelay = 20 * time.Millisecond
+	c.RetryConfig.Strategy = BackoffConstant
+	c.RetryConfig.MaxElapsed = 50 * time.Millisecond
+
+	started := time.Now()
+	_, err := c.GetSeats(context.Background())
+	if err == nil {
+		t.Fatal("GetSeats() error = nil, want the 503")
+	}
+	if !strings.Contains(err.Error(), "retry time budget") {
+		t.Errorf("GetSeats() error = %q, want it to report the exhausted time budget", err)
+	}
+	if got := requests.Load(); got >= 11 {
+		t.Errorf("requests = %d, want fewer than MaxRetries+1 = 11", got)
+	}
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Errorf("GetSeats() took %s, want it bounded by MaxElapsed", elapsed)
+	}
+}
+
+func TestMaxElapsedZeroIsUnlimited(t *testing.T) {
+	var requests atomic.Int32
+	c := newTestClient(t, countingHandler(http.StatusServiceUnavailable, &requests))
+	c.RetryConfig.MaxRetries = 3
+	c.RetryConfig.MaxElapsed = 0
+
+	if _, err := c.GetSeats(context.Background()); err == nil {
+		t.Fatal("GetSeats() error = nil, want the 503")
+	}
+	if got := requests.Load(); got != 4 {
+		t.Errorf("requests = %d, want MaxRetries+1 = 4", got)
+	}
+}
+
+func TestMaxElapsedStopsSuccessFalseRetries(t *testing.T) {
+	var requests atomic.Int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(`{"success":false,"message":"backend busy"}`))
+	}))
+	c.RetryConfig.MaxRetries = 10
+	c.RetryConfig.BaseDelay = 20 * time.Millisecond
+	c.RetryConfig.MaxDelay = 20 * time.Millisecond
+	c.RetryConfig.Strategy = BackoffConstant
+	c.RetryConfig.MaxElapsed = 50 * time.Millisecond
+
+	started := time.Now()
+	err := c.AssignSeat(context.Background(), "1")
+	if err == nil {
+		t.Fatal("AssignSeat() error = nil, want success:false to fail")
+	}
+	if !strings.Contains(err.Error(), "retry time budget") || !strings.Contains(err.Error(), "backend busy") {
+		t.Errorf("AssignSeat() error = %q, want the exhausted time budget and the API message", err)
+	}
+	if got := requests.Load(); got >= 11 {
+		t.Errorf("requests = %d, want fewer than MaxRetries+1 = 11", got)
+	}
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Errorf("AssignSeat() took %s, want it bounded by MaxElapsed", elapsed)
+	}
+}
@@ -0,0 +1,144 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestGetSeatsRejectsUnexpectedShape(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"users":[{"git_user_id":"1","seat_assigned":true}]}}`))
+	}))
+
+	_, err := c.GetSeats(context.Background())
+	if err == nil {
+		t.Fatal("GetSeats() error = nil, want an error for a wrapped payload")
+	}
+	if !strings.Contains(err.Error(), "users") {
+		t.Errorf("GetSeats() error = %q, want it to mention the missing users field", err)
+	}
+}
+
+func TestGetSeatsAcceptsEmptyResponses(t *testing.T) {
+	for _, body := range []string{`{"users":[]}`, `{}`, `{"users":null,"next_cursor":""}`} {
+		t.Run(body, func(t *testing.T) {
+			c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(body))
+			}))
+			seats, err := c.GetSeats(context.Background())
+			if err != nil {
+				t.Fatalf("GetSeats() error = %v", err)
+			}
+			if len(seats.Users) != 0 {
+				t.Errorf("GetSeats() users = %v, want none", seats.Users)
+			}
+		})
+	}
+}
+
+func TestGetSeatsNotFoundIsEmpty(t *testing.T) {
+	var requests atomic.Int32
+	c := newTestClient(t, countingHandler(http.StatusNotFound, &requests))
+
+	ctx := context.Background()
+	seats, err := c.GetSeats(ctx)
+	if err != nil {
+		t.Fatalf("GetSeats() error = %v, want an empty seats list for a 404", err)
+	}
+	if len(seats.Users) != 0 {
+		t.Errorf("GetSeats() users = %v, want none", seats.Users)
+	}
+	if ok, err := c.HasSeat(ctx, "1"); err != nil || ok {
+		t.Errorf("HasSeat() = %v, %v, want false", ok, err)
+	}
+}
+
+func TestGetSeatsOtherClientErrorsFail(t *testing.T) {
+	for _, status := range []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			var requests atomic.Int32
+			c := newTestClient(t, countingHandler(status, &requests))
+			if _, err := c.GetSeats(context.Background()); err == nil {
+				t.Errorf("GetSeats() with status %d error = nil, want an error", status)
+			}
+		})
+	}
+}
+
+func TestGetSeatsNotFoundOnLaterPageFails(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("cursor") != "" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"users":[{"git_user_id":"1","seat_assigned":true}],"next_cursor":"2"}`))
+	}))
+
+	if _, err := c.GetSeats(context.Background()); err == nil {
+		t.Error("GetSeats() error = nil, want a 404 on the second page to fail rather than drop seats")
+	}
+}
+
+func TestSeatUserUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want SeatUser
+	}{
+		{
+			name: "snake_case",
+			json: `{"git_user_id":"12345","seat_assigned":true,"assigned_at":"2024-01-01T00:00:00Z","role":"admin"}`,
+			want: SeatUser{GitUserID: "12345", SeatAssigned: true, AssignedAt: "2024-01-01T00:00:00Z", Role: "admin"},
+		},
+		{
+			name: "camelCase",
+			json: `{"userId":"12345","hasSeat":true,"assignedAt":"2024-01-01T00:00:00Z","role":"reviewer"}`,
+			want: SeatUser{GitUserID: "12345", SeatAssigned: true, AssignedAt: "2024-01-01T00:00:00Z", Role: "reviewer"},
+		},
+		{
+			name: "numeric ID",
+			json: `{"userId":12345,"hasSeat":false}`,
+			want: SeatUser{GitUserID: "12345"},
+		},
+		{
+			name: "snake_case wins",
+			json: `{"git_user_id":"1","userId":"2","seat_assigned":false,"hasSeat":true}`,
+			want: SeatUser{GitUserID: "1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got SeatUser
+			if err := json.Unmarshal([]byte(tt.json), &got); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("json.Unmarshal() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	var user SeatUser
+	if err := json.Unmarshal([]byte(`{"git_user_id":true}`), &user); err == nil {
+		t.Error("json.Unmarshal() with a boolean ID error = nil, want an error")
+	}
+}
+
+func TestGetSeatsCamelCasePayload(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"users":[{"userId":12345,"hasSeat":true}]}`))
+	}))
+
+	ok, err := c.HasSeat(context.Background(), "12345")
+	if err != nil {
+		t.Fatalf("HasSeat() error = %v", err)
+	}
+	if !ok {
+		t.Error("HasSeat() = false, want the camelCase seat to count")
+	}
+}
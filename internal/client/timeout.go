@@ -0,0 +1,43 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// requestTimeoutContextKey is the context key carrying a per-call attempt timeout
+type requestTimeoutContextKey struct{}
+
+// withRequestTimeout returns a context whose CodeRabbit requests give each
+// attempt timeout instead of HTTPClient.Timeout. A non-positive timeout
+// leaves ctx unchanged, so the client-level timeout applies.
+func withRequestTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	if timeout <= 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, requestTimeoutContextKey{}, timeout)
+}
+
+// requestTimeout returns the per-call attempt timeout of ctx, or 0 if it has none
+func requestTimeout(ctx context.Context) time.Duration {
+	timeout, _ := ctx.Value(requestTimeoutContextKey{}).(time.Duration)
+	return timeout
+}
+
+// attemptClient returns the context and HTTP client for a single attempt of a
+// CodeRabbit request. When ctx carries a per-call timeout, the attempt gets
+// its own deadline and the client-level timeout is lifted so that the per-call
+// timeout can be longer. The returned cancel func must be called once the
+// response body has been read.
+func (c *Client) attemptClient(ctx context.Context) (context.Context, *http.Client, context.CancelFunc) {
+	timeout := requestTimeout(ctx)
+	if timeout <= 0 {
+		return ctx, c.HTTPClient, func() {}
+	}
+
+	httpClient := *c.HTTPClient
+	httpClient.Timeout = 0
+	attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+	return attemptCtx, &httpClient, cancel
+}
@@ -0,0 +1,119 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// newTransport returns the HTTP transport used for all outbound requests.
+// Proxies are taken from HTTP_PROXY/HTTPS_PROXY/NO_PROXY unless overridden
+// with SetProxyURL.
+func newTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	return transport
+}
+
+// SetProxyURL routes all CodeRabbit and GitHub requests through the given proxy
+func (c *Client) SetProxyURL(proxyURL string) error {
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("invalid proxy URL %q: scheme and host are required", proxyURL)
+	}
+
+	c.transport.Proxy = http.ProxyURL(u)
+	c.githubTransport.Proxy = http.ProxyURL(u)
+	return nil
+}
+
+// SetCACertFile trusts the PEM-encoded certificates in the given file in
+// addition to the system roots, for CodeRabbit and GitHub Enterprise hosts
+// behind an internal CA
+func (c *Client) SetCACertFile(caCertFile string) error {
+	pemData, err := os.ReadFile(caCertFile)
+	if err != nil {
+		return fmt.Errorf("failed to read CA certificate file: %w", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pemData) {
+		return fmt.Errorf("no valid PEM certificates found in %s", caCertFile)
+	}
+
+	tlsConfig(c.transport).RootCAs = pool
+	tlsConfig(c.githubTransport).RootCAs = pool
+	return nil
+}
+
+// SetInsecureSkipVerify disables TLS certificate verification for CodeRabbit
+// API requests only. GitHub requests are always verified.
+func (c *Client) SetInsecureSkipVerify(insecure bool) {
+	tlsConfig(c.transport).InsecureSkipVerify = insecure //nolint:gosec // explicitly requested by the user for local testing
+}
+
+// SetForceHTTP1 restricts CodeRabbit and GitHub requests to HTTP/1.1, for
+// proxies and other middleboxes that mishandle HTTP/2
+func (c *Client) SetForceHTTP1(force bool) {
+	for _, transport := range []*http.Transport{c.transport, c.githubTransport} {
+		if !force {
+			transport.ForceAttemptHTTP2 = true
+			transport.TLSNextProto = nil
+			tlsConfig(transport).NextProtos = nil
+			continue
+		}
+
+		// A non-nil, empty TLSNextProto disables the HTTP/2 upgrade
+		transport.ForceAttemptHTTP2 = false
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+		tlsConfig(transport).NextProtos = []string{"http/1.1"}
+	}
+}
+
+// SetMaxIdleConns sets the maximum number of idle keep-alive connections kept
+// across all hosts by each of the CodeRabbit and GitHub transports. Zero
+// means no limit.
+func (c *Client) SetMaxIdleConns(n int) {
+	c.transport.MaxIdleConns = n
+	c.githubTransport.MaxIdleConns = n
+}
+
+// SetMaxIdleConnsPerHost sets the maximum number of idle keep-alive
+// connections kept per host. Zero uses Go's default of 2; raise it for bulk
+// operations with a higher concurrency.
+func (c *Client) SetMaxIdleConnsPerHost(n int) {
+	c.transport.MaxIdleConnsPerHost = n
+	c.githubTransport.MaxIdleConnsPerHost = n
+}
+
+// SetIdleConnTimeout sets how long an idle keep-alive connection is kept
+// before it is closed. Zero means no limit.
+func (c *Client) SetIdleConnTimeout(d time.Duration) {
+	c.transport.IdleConnTimeout = d
+	c.githubTransport.IdleConnTimeout = d
+}
+
+// Close releases the idle keep-alive connections of the CodeRabbit and GitHub
+// transports. The client remains usable; later requests open new connections.
+func (c *Client) Close() {
+	c.transport.CloseIdleConnections()
+	c.githubTransport.CloseIdleConnections()
+}
+
+// tlsConfig returns the transport's TLS config, creating it if needed
+func tlsConfig(transport *http.Transport) *tls.Config {
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	return transport.TLSClientConfig
+}
@@ -0,0 +1,150 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Supported Git platforms for user resolution
+const (
+	ProviderTypeGitHub    = "github"
+	ProviderTypeGitLab    = "gitlab"
+	ProviderTypeBitbucket = "bitbucket"
+)
+
+// DefaultGitLabBaseURL is the REST API root for gitlab.com
+const DefaultGitLabBaseURL = "https://gitlab.com/api/v4"
+
+// DefaultBitbucketBaseURL is the REST API root for Bitbucket Cloud
+const DefaultBitbucketBaseURL = "https://api.bitbucket.org/2.0"
+
+// gitLabUserResponse represents a user in the response from GitLab GET /users
+type gitLabUserResponse struct {
+	ID       int    `json:"id"`
+	Username string `json:"username"`
+}
+
+// bitbucketUserResponse represents the response from Bitbucket GET /users/{user}
+type bitbucketUserResponse struct {
+	AccountID string `json:"account_id"`
+	UUID      string `json:"uuid"`
+}
+
+// doVCSRequestCtx performs a GET request against a GitLab or Bitbucket API with retry logic
+func (c *Client) doVCSRequestCtx(ctx context.Context, platform, reqURL, token string) ([]byte, int, error) {
+	var lastErr error
+	for attempt := 0; attempt <= c.RetryConfig.MaxRetries; attempt++ {
+		if attempt > 0 {
+			if err := sleepCtx(ctx, c.calculateBackoff(attempt-1)); err != nil {
+				return nil, 0, fmt.Errorf("%s API request cancelled: %w", platform, err)
+			}
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to create %s API request: %w", platform, err)
+		}
+
+		c.setDefaultHeaders(req)
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("User-Agent", c.UserAgent)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		start := time.Now()
+		resp, err := c.GitHubHTTPClient.Do(req)
+		if err != nil {
+			c.logAttempt(ctx, req, attempt, 0, time.Since(start), err)
+			if ctx.Err() != nil {
+				return nil, 0, fmt.Errorf("%s API request cancelled: %w", platform, ctx.Err())
+			}
+			lastErr = fmt.Errorf("failed to perform %s API request: %w", platform, err)
+			continue
+		}
+
+		respBody, err := c.readBody(resp)
+		c.logAttempt(ctx, req, attempt, resp.StatusCode, time.Since(start), err)
+		if errors.Is(err, ErrResponseTooLarge) {
+			return nil, 0, err
+		}
+		if err != nil {
+			lastErr = fmt.Errorf("failed to read %s API response: %w", platform, err)
+			continue
+		}
+
+		if c.isRetryableStatus(resp.StatusCode) {
+			lastErr = fmt.Errorf("%s API error (status %d)", platform, resp.StatusCode)
+			continue
+		}
+
+		if resp.StatusCode >= 400 {
+			return nil, resp.StatusCode, fmt.Errorf("%s API error (status %d)", platform, resp.StatusCode)
+		}
+
+		return respBody, resp.StatusCode, nil
+	}
+
+	return nil, 0, fmt.Errorf("%s API request failed after %d retries: %w", platform, c.RetryConfig.MaxRetries, lastErr)
+}
+
+// GetGitLabUserID resolves a GitLab username to a numeric user ID
+func (c *Client) GetGitLabUserID(ctx context.Context, username string) (string, error) {
+	reqURL := strings.TrimRight(c.GitLabBaseURL, "/") + "/users?" + url.Values{"username": {username}}.Encode()
+	respBody, _, err := c.doVCSRequestCtx(ctx, "GitLab", reqURL, c.GitLabToken)
+	if err != nil {
+		return "", err
+	}
+
+	var users []gitLabUserResponse
+	if err := json.Unmarshal(respBody, &users); err != nil {
+		return "", fmt.Errorf("failed to parse GitLab API response: %w", err)
+	}
+	if len(users) == 0 {
+		return "", fmt.Errorf("GitLab user '%s' not found", username)
+	}
+
+	return fmt.Sprintf("%d", users[0].ID), nil
+}
+
+// GetBitbucketUserID resolves a Bitbucket account (account ID or UUID) to its account ID
+func (c *Client) GetBitbucketUserID(ctx context.Context, username string) (string, error) {
+	reqURL := strings.TrimRight(c.BitbucketBaseURL, "/") + "/users/" + url.PathEscape(username)
+	respBody, statusCode, err := c.doVCSRequestCtx(ctx, "Bitbucket", reqURL, c.BitbucketToken)
+	if err != nil {
+		if statusCode == http.StatusNotFound {
+			return "", fmt.Errorf("Bitbucket user '%s' not found", username)
+		}
+		return "", err
+	}
+
+	var user bitbucketUserResponse
+	if err := json.Unmarshal(respBody, &user); err != nil {
+		return "", fmt.Errorf("failed to parse Bitbucket API response: %w", err)
+	}
+	if user.AccountID == "" {
+		return "", fmt.Errorf("Bitbucket user '%s' has no account ID", username)
+	}
+
+	return user.AccountID, nil
+}
+
+// ResolveUserID resolves a username on the given Git platform to the ID CodeRabbit uses as git_user_id
+func (c *Client) ResolveUserID(ctx context.Context, providerType, username string) (string, error) {
+	switch providerType {
+	case "", ProviderTypeGitHub:
+		return c.GetGitUserID(ctx, username)
+	case ProviderTypeGitLab:
+		return c.GetGitLabUserID(ctx, username)
+	case ProviderTypeBitbucket:
+		return c.GetBitbucketUserID(ctx, username)
+	default:
+		return "", fmt.Errorf("unsupported provider type '%s'", providerType)
+	}
+}
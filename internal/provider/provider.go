@@ -2,11 +2,19 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/url"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/coderabbitai/terraform-provider-coderabbit/internal/client"
 	"github.com/coderabbitai/terraform-provider-coderabbit/internal/resources"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -22,8 +30,66 @@ type CodeRabbitProvider struct {
 
 type CodeRabbitProviderModel struct {
 	APIKey      types.String `tfsdk:"api_key"`
+	APIKeyFile  types.String `tfsdk:"api_key_file"`
 	BaseURL     types.String `tfsdk:"base_url"`
+	APIVersion  types.String `tfsdk:"api_version"`
 	GitHubToken types.String `tfsdk:"github_token"`
+	UserAgent   types.String `tfsdk:"user_agent"`
+	ProxyURL    types.String `tfsdk:"proxy_url"`
+	CACertFile  types.String `tfsdk:"ca_cert_file"`
+	Insecure    types.Bool   `tfsdk:"insecure"`
+	DryRun      types.Bool   `tfsdk:"dry_run"`
+
+	DisableCache types.Bool `tfsdk:"disable_cache"`
+	ForceHTTP1   types.Bool `tfsdk:"force_http1"`
+
+	ValidateCredentials types.Bool  `tfsdk:"validate_credentials"`
+	DefaultHeaders      types.Map   `tfsdk:"default_headers"`
+	MaxResponseBytes    types.Int64 `tfsdk:"max_response_bytes"`
+
+	ValidateGitHubScopes types.Bool `tfsdk:"validate_github_scopes"`
+
+	RequestTimeout types.String `tfsdk:"request_timeout"`
+	MaxRetries     types.Int64  `tfsdk:"max_retries"`
+	RetryBaseDelay types.String `tfsdk:"retry_base_delay"`
+	RetryMaxDelay  types.String `tfsdk:"retry_max_delay"`
+	RetryBackoff   types.String `tfsdk:"retry_backoff"`
+
+	RetryMaxElapsed types.String `tfsdk:"retry_max_elapsed"`
+	RetryBudget     types.Int64  `tfsdk:"retry_budget"`
+	SeatWaitTimeout types.String `tfsdk:"seat_wait_timeout"`
+
+	RequestsPerSecond types.Float64 `tfsdk:"requests_per_second"`
+
+	CircuitBreakerThreshold types.Int64  `tfsdk:"circuit_breaker_threshold"`
+	CircuitBreakerWindow    types.String `tfsdk:"circuit_breaker_window"`
+	CircuitBreakerCooldown  types.String `tfsdk:"circuit_breaker_cooldown"`
+
+	SeatsReadTimeout  types.String `tfsdk:"seats_read_timeout"`
+	SeatsWriteTimeout types.String `tfsdk:"seats_write_timeout"`
+
+	MaxIdleConns        types.Int64  `tfsdk:"max_idle_conns"`
+	MaxIdleConnsPerHost types.Int64  `tfsdk:"max_idle_conns_per_host"`
+	IdleConnTimeout     types.String `tfsdk:"idle_conn_timeout"`
+
+	SeatsPath        types.String `tfsdk:"seats_path"`
+	AssignSeatPath   types.String `tfsdk:"assign_seat_path"`
+	UnassignSeatPath types.String `tfsdk:"unassign_seat_path"`
+
+	FallbackBaseURL types.String `tfsdk:"fallback_base_url"`
+	GitHubBaseURL   types.String `tfsdk:"github_base_url"`
+
+	GitHubAppID             types.Int64  `tfsdk:"github_app_id"`
+	GitHubAppInstallationID types.Int64  `tfsdk:"github_app_installation_id"`
+	GitHubAppPrivateKeyFile types.String `tfsdk:"github_app_private_key_file"`
+
+	OIDCTokenEndpoint types.String `tfsdk:"oidc_token_endpoint"`
+	OIDCTokenFile     types.String `tfsdk:"oidc_token_file"`
+
+	GitLabToken      types.String `tfsdk:"gitlab_token"`
+	GitLabBaseURL    types.String `tfsdk:"gitlab_base_url"`
+	BitbucketToken   types.String `tfsdk:"bitbucket_token"`
+	BitbucketBaseURL types.String `tfsdk:"bitbucket_base_url"`
 }
 
 func New(version string) func() provider.Provider {
@@ -48,15 +114,194 @@ func (p *CodeRabbitProvider) Schema(ctx context.Context, req provider.SchemaRequ
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"api_key_file": schema.StringAttribute{
+				Description: "Path to a file containing the CodeRabbit API key, e.g. a secret mounted by Vault Agent. Takes precedence over CODERABBITAI_API_KEY. Cannot be combined with api_key.",
+				Optional:    true,
+			},
+			"oidc_token_endpoint": schema.StringAttribute{
+				Description: "Token endpoint that exchanges a workload identity (OIDC) token for a short-lived CodeRabbit token, using RFC 8693 token exchange. When set, no API key is needed and the token is refreshed before it expires. Can also be set via CODERABBIT_OIDC_TOKEN_ENDPOINT environment variable.",
+				Optional:    true,
+			},
+			"oidc_token_file": schema.StringAttribute{
+				Description: "Path to a file containing the OIDC token to exchange, re-read on every exchange so rotated tokens are picked up. Defaults to the TF_WORKLOAD_IDENTITY_TOKEN or TFC_WORKLOAD_IDENTITY_TOKEN environment variable.",
+				Optional:    true,
+			},
 			"base_url": schema.StringAttribute{
-				Description: "Base URL for CodeRabbit API. Defaults to https://api.coderabbit.ai. Can also be set via CODERABBIT_BASE_URL environment variable.",
+				Description: "Base URL for CodeRabbit API. Must be an http or https URL with a host; trailing slashes are ignored. Defaults to https://api.coderabbit.ai. Can also be set via CODERABBIT_BASE_URL environment variable.",
+				Optional:    true,
+			},
+			"fallback_base_url": schema.StringAttribute{
+				Description: "Secondary CodeRabbit API base URL. When base_url is unreachable after all retries, the request is retried once against this host, which is then used for the rest of the run. Can also be set via CODERABBIT_FALLBACK_BASE_URL environment variable.",
+				Optional:    true,
+			},
+			"api_version": schema.StringAttribute{
+				Description: "API version path prefix inserted after base_url, e.g. v1. Defaults to v1. Set to an empty string to omit the prefix, e.g. for staging endpoints. Can also be set via CODERABBIT_API_VERSION environment variable.",
+				Optional:    true,
+			},
+			"seats_path": schema.StringAttribute{
+				Description: "Path of the endpoint listing seats, relative to the API version prefix. Defaults to /seats/. Only needed when CodeRabbit serves seats from a different route.",
+				Optional:    true,
+			},
+			"assign_seat_path": schema.StringAttribute{
+				Description: "Path of the endpoint assigning a seat, relative to the API version prefix. Defaults to /seats/assign.",
+				Optional:    true,
+			},
+			"unassign_seat_path": schema.StringAttribute{
+				Description: "Path of the endpoint unassigning a seat, relative to the API version prefix. Defaults to /seats/unassign.",
 				Optional:    true,
 			},
 			"github_token": schema.StringAttribute{
-				Description: "GitHub personal access token for GitHub API authentication. Can also be set via GITHUB_TOKEN environment variable. If not set, GitHub API requests will be unauthenticated (lower rate limits).",
+				Description: "GitHub personal access token for GitHub API authentication. Can also be set via environment variables; precedence is this attribute, then CODERABBIT_GITHUB_TOKEN, then GITHUB_TOKEN, then GH_TOKEN. If not set, GitHub API requests will be unauthenticated (lower rate limits).",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"github_base_url": schema.StringAttribute{
+				Description: "Base URL for the GitHub REST API used to resolve usernames. Defaults to https://api.github.com. For GitHub Enterprise Server, set this to your instance URL; /api/v3 is appended if missing. Can also be set via GITHUB_BASE_URL environment variable.",
+				Optional:    true,
+			},
+			"github_app_id": schema.Int64Attribute{
+				Description: "GitHub App ID used to mint short-lived installation tokens for GitHub API requests. Requires github_app_installation_id and github_app_private_key_file. Cannot be combined with github_token, but takes precedence over a GitHub token from environment variables.",
+				Optional:    true,
+			},
+			"github_app_installation_id": schema.Int64Attribute{
+				Description: "Installation ID of the GitHub App configured via github_app_id.",
+				Optional:    true,
+			},
+			"github_app_private_key_file": schema.StringAttribute{
+				Description: "Path to the PEM-encoded private key of the GitHub App configured via github_app_id.",
+				Optional:    true,
+			},
+			"gitlab_token": schema.StringAttribute{
+				Description: "GitLab personal access token used to resolve usernames for seats with provider_type gitlab. Can also be set via GITLAB_TOKEN environment variable.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"gitlab_base_url": schema.StringAttribute{
+				Description: "Base URL for the GitLab REST API. Defaults to https://gitlab.com/api/v4. Can also be set via GITLAB_BASE_URL environment variable.",
+				Optional:    true,
+			},
+			"bitbucket_token": schema.StringAttribute{
+				Description: "Bitbucket access token used to resolve users for seats with provider_type bitbucket. Can also be set via BITBUCKET_TOKEN environment variable.",
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"bitbucket_base_url": schema.StringAttribute{
+				Description: "Base URL for the Bitbucket REST API. Defaults to https://api.bitbucket.org/2.0. Can also be set via BITBUCKET_BASE_URL environment variable.",
+				Optional:    true,
+			},
+			"user_agent": schema.StringAttribute{
+				Description: "User-Agent header sent with all API requests. Defaults to terraform-provider-coderabbit/<version>. Useful behind gateways that require a specific User-Agent.",
+				Optional:    true,
+			},
+			"default_headers": schema.MapAttribute{
+				Description: "Additional headers sent with every CodeRabbit, GitHub, GitLab and Bitbucket API request, e.g. headers required by a corporate gateway. Authentication headers (x-coderabbitai-api-key, Authorization), Host and Idempotency-Key cannot be set.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"proxy_url": schema.StringAttribute{
+				Description: "URL of an HTTP(S) proxy used for all CodeRabbit and GitHub API requests. If not set, the standard HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are honored.",
+				Optional:    true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Description: "Path to a PEM-encoded CA certificate bundle to trust in addition to the system roots. Use this for self-hosted CodeRabbit or GitHub Enterprise deployments behind an internal CA.",
+				Optional:    true,
+			},
+			"insecure": schema.BoolAttribute{
+				Description: "Skip TLS certificate verification for CodeRabbit API requests. Intended only for testing against local endpoints with self-signed certificates; GitHub requests are always verified. Can also be set via CODERABBIT_INSECURE environment variable.",
+				Optional:    true,
+			},
+			"force_http1": schema.BoolAttribute{
+				Description: "Use HTTP/1.1 for all CodeRabbit and GitHub API requests instead of negotiating HTTP/2. Set this when a corporate proxy or other middlebox mishandles HTTP/2 and requests fail with intermittent stream errors. Defaults to false.",
+				Optional:    true,
+			},
+			"dry_run": schema.BoolAttribute{
+				Description: "Log seat assign and unassign calls instead of sending them, so a configuration can be applied against the live API without changing any seats. Reads still hit the API. Can also be set via CODERABBIT_DRY_RUN environment variable.",
+				Optional:    true,
+			},
+			"disable_cache": schema.BoolAttribute{
+				Description: "Fetch the seat and repository lists on every lookup instead of caching them for 60 seconds. Use this when seats are changed outside Terraform during a run, e.g. by a provisioner script. Large configurations make many more API requests with the cache disabled. Defaults to false.",
+				Optional:    true,
+			},
+			"validate_credentials": schema.BoolAttribute{
+				Description: "Check the API key against the CodeRabbit API when the provider is configured, so that a rejected key or unreachable base_url fails early with a clear message. Defaults to true. Set to false for air-gapped setups or to save the extra request.",
+				Optional:    true,
+			},
+			"validate_github_scopes": schema.BoolAttribute{
+				Description: "Check the scopes of a classic GitHub personal access token when a feature needing one is used, and warn if it is missing: read:org when planning coderabbit_team_seats, and user:email when creating a coderabbit_seats resource by email. Configurations using neither make no extra request. Fine-grained tokens and GitHub Apps do not report scopes and are not checked. Defaults to true. Set to false to skip the check.",
+				Optional:    true,
+			},
+			"request_timeout": schema.StringAttribute{
+				Description: "Timeout for each HTTP request attempt to the CodeRabbit and GitHub APIs as a Go duration string, e.g. 60s. Defaults to 30s. Set to 0 to disable the client-level timeout and rely on Terraform's operation timeouts.",
+				Optional:    true,
+			},
+			"seats_read_timeout": schema.StringAttribute{
+				Description: "Timeout for each attempt to fetch a page of seats from the CodeRabbit API, as a Go duration string, e.g. 5m. Replaces request_timeout for these requests, since listing a large organization's seats can take much longer than a single assignment. Defaults to request_timeout.",
+				Optional:    true,
+			},
+			"seats_write_timeout": schema.StringAttribute{
+				Description: "Timeout for each seat assign or unassign request attempt to the CodeRabbit API, as a Go duration string, e.g. 15s. Replaces request_timeout for these requests. Defaults to request_timeout.",
+				Optional:    true,
+			},
+			"max_response_bytes": schema.Int64Attribute{
+				Description: "Maximum size in bytes of any API response body. Larger responses fail with an error instead of being read into memory. Defaults to 10485760 (10 MiB). Set to 0 to disable the limit.",
+				Optional:    true,
+			},
+			"max_retries": schema.Int64Attribute{
+				Description: "Maximum number of retries for failed API requests. Defaults to 3. Set to 0 to disable retries.",
+				Optional:    true,
+			},
+			"retry_base_delay": schema.StringAttribute{
+				Description: "Initial delay between retries as a Go duration string, e.g. 1s or 500ms. Later delays grow from it according to retry_backoff. Defaults to 1s.",
+				Optional:    true,
+			},
+			"retry_max_delay": schema.StringAttribute{
+				Description: "Maximum delay between retries as a Go duration string, e.g. 30s. Must not be less than retry_base_delay. Defaults to 30s.",
+				Optional:    true,
+			},
+			"retry_backoff": schema.StringAttribute{
+				Description: "How the delay between retries grows: exponential doubles it after each retry, linear adds retry_base_delay each time, and constant always waits retry_base_delay, which suits strict fixed-rate limits. Every strategy is capped at retry_max_delay. Defaults to exponential.",
+				Optional:    true,
+			},
+			"retry_max_elapsed": schema.StringAttribute{
+				Description: "Upper bound on the total time a single API call may spend on attempts and backoff, as a Go duration string, e.g. 2m. No further retries are started once it would be exceeded. Defaults to no limit beyond max_retries.",
+				Optional:    true,
+			},
+			"retry_budget": schema.Int64Attribute{
+				Description: "Total number of retries the provider may perform across all API calls in one run. Once spent, failing calls return their error without retrying, so a widespread outage fails fast instead of retrying every resource. Defaults to 0, meaning unlimited.",
+				Optional:    true,
+			},
+			"requests_per_second": schema.Float64Attribute{
+				Description: "Maximum rate of CodeRabbit API requests per second, shared by every resource and data source using this provider, e.g. 5 or 0.5. Smooths large applies that would otherwise hit the API rate limit. Defaults to 0, meaning unlimited.",
+				Optional:    true,
+			},
+			"circuit_breaker_threshold": schema.Int64Attribute{
+				Description: "Number of failed CodeRabbit API attempts (5xx responses or connection errors) within circuit_breaker_window after which further requests fail fast instead of retrying, so an outage doesn't multiply retries across every resource. Rate limited (429) responses don't count. Defaults to 0, which disables the circuit breaker.",
+				Optional:    true,
+			},
+			"circuit_breaker_window": schema.StringAttribute{
+				Description: "Window in which circuit_breaker_threshold failures open the circuit, as a Go duration string, e.g. 1m. Defaults to 1m.",
+				Optional:    true,
+			},
+			"circuit_breaker_cooldown": schema.StringAttribute{
+				Description: "How long an open circuit fails requests fast before letting a single probe request through, as a Go duration string, e.g. 30s. Defaults to 30s.",
+				Optional:    true,
+			},
+			"max_idle_conns": schema.Int64Attribute{
+				Description: "Maximum number of idle keep-alive connections kept across all hosts. Defaults to 100. Set to 0 for no limit.",
+				Optional:    true,
+			},
+			"max_idle_conns_per_host": schema.Int64Attribute{
+				Description: "Maximum number of idle keep-alive connections kept per host. Defaults to 2; raise it to reuse connections when bulk resources run with a high concurrency.",
+				Optional:    true,
+			},
+			"idle_conn_timeout": schema.StringAttribute{
+				Description: "How long an idle keep-alive connection is kept open, as a Go duration string, e.g. 90s. Defaults to 90s. Set to 0 for no limit.",
+				Optional:    true,
+			},
+			"seat_wait_timeout": schema.StringAttribute{
+				Description: "How long coderabbit_seats waits after assigning a seat for it to appear in the seats list, as a Go duration string, e.g. 1m. The CodeRabbit API is eventually consistent, so a new seat may briefly be missing. Defaults to 30s. Set to 0 to disable waiting.",
+				Optional:    true,
+			},
 		},
 	}
 }
@@ -69,18 +314,52 @@ func (p *CodeRabbitProvider) Configure(ctx context.Context, req provider.Configu
 		return
 	}
 
-	// Get API key from config or environment variable
+	// Get API key from config, key file or environment variable
 	apiKey := os.Getenv("CODERABBITAI_API_KEY")
+	if !config.APIKeyFile.IsNull() && config.APIKey.IsNull() {
+		keyData, err := os.ReadFile(config.APIKeyFile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("api_key_file"),
+				"Unable to Read CodeRabbit API Key File",
+				fmt.Sprintf("Could not read API key file: %s", err.Error()),
+			)
+			return
+		}
+		apiKey = strings.TrimRight(string(keyData), "\r\n")
+		if apiKey == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("api_key_file"),
+				"Empty CodeRabbit API Key File",
+				fmt.Sprintf("The API key file %s is empty.", config.APIKeyFile.ValueString()),
+			)
+			return
+		}
+	}
 	if !config.APIKey.IsNull() {
 		apiKey = config.APIKey.ValueString()
 	}
 
-	if apiKey == "" {
+	// Get the OIDC token endpoint from config or environment variable
+	oidcTokenEndpoint := os.Getenv("CODERABBIT_OIDC_TOKEN_ENDPOINT")
+	if !config.OIDCTokenEndpoint.IsNull() {
+		oidcTokenEndpoint = config.OIDCTokenEndpoint.ValueString()
+	}
+	if oidcTokenEndpoint != "" && (!config.APIKey.IsNull() || !config.APIKeyFile.IsNull()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("oidc_token_endpoint"),
+			"Conflicting CodeRabbit Credentials",
+			"oidc_token_endpoint cannot be combined with api_key or api_key_file. Remove one of them.",
+		)
+		return
+	}
+
+	if apiKey == "" && oidcTokenEndpoint == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("api_key"),
 			"Missing CodeRabbit API Key",
 			"The provider cannot create the CodeRabbit API client because the API key is missing. "+
-				"Set the api_key attribute in the provider configuration or set the CODERABBITAI_API_KEY environment variable.",
+				"Set the api_key or api_key_file attribute in the provider configuration, set the CODERABBITAI_API_KEY environment variable, or configure oidc_token_endpoint.",
 		)
 		return
 	}
@@ -93,29 +372,533 @@ func (p *CodeRabbitProvider) Configure(ctx context.Context, req provider.Configu
 	if baseURL == "" {
 		baseURL = "https://api.coderabbit.ai"
 	}
+	baseURL, ok := normalizeBaseURL(baseURL, "base_url", &resp.Diagnostics)
+	if !ok {
+		return
+	}
 
-	// Get GitHub token from config or environment variable
-	githubToken := os.Getenv("GITHUB_TOKEN")
+	// Get GitHub token from config or environment variables
+	githubToken := os.Getenv("CODERABBIT_GITHUB_TOKEN")
+	if githubToken == "" {
+		githubToken = os.Getenv("GITHUB_TOKEN")
+	}
+	if githubToken == "" {
+		githubToken = os.Getenv("GH_TOKEN")
+	}
 	if !config.GitHubToken.IsNull() {
 		githubToken = config.GitHubToken.ValueString()
 	}
 
+	// Get GitHub base URL from config or environment variable
+	githubBaseURL := os.Getenv("GITHUB_BASE_URL")
+	if !config.GitHubBaseURL.IsNull() {
+		githubBaseURL = config.GitHubBaseURL.ValueString()
+	}
+
 	// Create API client
-	c := client.NewClient(apiKey, baseURL, githubToken)
+	c := client.NewClient(apiKey, baseURL, githubToken, p.version)
+	c.GitHubBaseURL = client.NormalizeGitHubBaseURL(githubBaseURL)
+
+	if oidcTokenEndpoint != "" {
+		subjectToken, ok := oidcSubjectToken(config.OIDCTokenFile, &resp.Diagnostics)
+		if !ok {
+			return
+		}
+		c.APIKey = ""
+		c.SetOIDC(oidcTokenEndpoint, subjectToken)
+	}
+
+	// Get GitLab and Bitbucket settings from config or environment variables
+	c.GitLabToken = os.Getenv("GITLAB_TOKEN")
+	if !config.GitLabToken.IsNull() {
+		c.GitLabToken = config.GitLabToken.ValueString()
+	}
+	if v := os.Getenv("GITLAB_BASE_URL"); v != "" {
+		c.GitLabBaseURL = v
+	}
+	if !config.GitLabBaseURL.IsNull() && config.GitLabBaseURL.ValueString() != "" {
+		c.GitLabBaseURL = config.GitLabBaseURL.ValueString()
+	}
+	c.BitbucketToken = os.Getenv("BITBUCKET_TOKEN")
+	if !config.BitbucketToken.IsNull() {
+		c.BitbucketToken = config.BitbucketToken.ValueString()
+	}
+	if v := os.Getenv("BITBUCKET_BASE_URL"); v != "" {
+		c.BitbucketBaseURL = v
+	}
+	if !config.BitbucketBaseURL.IsNull() && config.BitbucketBaseURL.ValueString() != "" {
+		c.BitbucketBaseURL = config.BitbucketBaseURL.ValueString()
+	}
+
+	// Configure GitHub App authentication when all of its settings are present
+	appSettings := 0
+	for _, set := range []bool{
+		!config.GitHubAppID.IsNull(),
+		!config.GitHubAppInstallationID.IsNull(),
+		!config.GitHubAppPrivateKeyFile.IsNull(),
+	} {
+		if set {
+			appSettings++
+		}
+	}
+	if appSettings > 0 && appSettings < 3 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("github_app_id"),
+			"Incomplete GitHub App Configuration",
+			"github_app_id, github_app_installation_id and github_app_private_key_file must all be set to authenticate as a GitHub App.",
+		)
+		return
+	}
+	if appSettings == 3 {
+		privateKey, err := os.ReadFile(config.GitHubAppPrivateKeyFile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("github_app_private_key_file"),
+				"Unable to Read GitHub App Private Key",
+				fmt.Sprintf("Could not read GitHub App private key file: %s", err.Error()),
+			)
+			return
+		}
+		if err := c.SetGitHubApp(config.GitHubAppID.ValueInt64(), config.GitHubAppInstallationID.ValueInt64(), privateKey); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("github_app_private_key_file"),
+				"Invalid GitHub App Private Key",
+				err.Error(),
+			)
+			return
+		}
+	}
+	fallbackBaseURL := os.Getenv("CODERABBIT_FALLBACK_BASE_URL")
+	if !config.FallbackBaseURL.IsNull() {
+		fallbackBaseURL = config.FallbackBaseURL.ValueString()
+	}
+	if fallbackBaseURL != "" {
+		if fallbackBaseURL, ok = normalizeBaseURL(fallbackBaseURL, "fallback_base_url", &resp.Diagnostics); !ok {
+			return
+		}
+	}
+	c.FallbackBaseURL = fallbackBaseURL
+
+	// An explicitly empty api_version omits the prefix, so only null falls back
+	if v, ok := os.LookupEnv("CODERABBIT_API_VERSION"); ok {
+		c.APIVersion = strings.Trim(v, "/")
+	}
+	if !config.APIVersion.IsNull() {
+		c.APIVersion = strings.Trim(config.APIVersion.ValueString(), "/")
+	}
+
+	setEndpointPath(&c.SeatsPath, config.SeatsPath)
+	setEndpointPath(&c.AssignSeatPath, config.AssignSeatPath)
+	setEndpointPath(&c.UnassignSeatPath, config.UnassignSeatPath)
+
+	if !config.UserAgent.IsNull() && config.UserAgent.ValueString() != "" {
+		c.UserAgent = config.UserAgent.ValueString()
+	}
+
+	if !config.DefaultHeaders.IsNull() {
+		headers := make(map[string]string, len(config.DefaultHeaders.Elements()))
+		resp.Diagnostics.Append(config.DefaultHeaders.ElementsAs(ctx, &headers, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for name := range headers {
+			if client.IsReservedHeader(name) {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("default_headers").AtMapKey(name),
+					"Reserved Header",
+					fmt.Sprintf("The %s header is set by the provider and cannot be overridden via default_headers.", name),
+				)
+			}
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		c.DefaultHeaders = headers
+	}
+
+	if !config.ProxyURL.IsNull() && config.ProxyURL.ValueString() != "" {
+		if err := c.SetProxyURL(config.ProxyURL.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("proxy_url"),
+				"Invalid Proxy URL",
+				fmt.Sprintf("The provider cannot use the configured proxy: %s", err.Error()),
+			)
+			return
+		}
+	}
+
+	if !config.CACertFile.IsNull() && config.CACertFile.ValueString() != "" {
+		if err := c.SetCACertFile(config.CACertFile.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ca_cert_file"),
+				"Invalid CA Certificate File",
+				fmt.Sprintf("The provider cannot load the configured CA certificates: %s", err.Error()),
+			)
+			return
+		}
+	}
+
+	// Get insecure flag from config or environment variable
+	insecure := false
+	if v := os.Getenv("CODERABBIT_INSECURE"); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid CODERABBIT_INSECURE Value",
+				fmt.Sprintf("CODERABBIT_INSECURE must be a boolean, got %q.", v),
+			)
+			return
+		}
+		insecure = parsed
+	}
+	if !config.Insecure.IsNull() {
+		insecure = config.Insecure.ValueBool()
+	}
+
+	if insecure {
+		c.SetInsecureSkipVerify(true)
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("insecure"),
+			"TLS Verification Disabled",
+			"TLS certificate verification is disabled for CodeRabbit API requests. "+
+				"This is insecure and should only be used for testing against local endpoints.",
+		)
+	}
+
+	if config.ForceHTTP1.ValueBool() {
+		c.SetForceHTTP1(true)
+	}
+
+	// Get connection pool settings from config, falling back to Go's defaults
+	for _, setting := range []struct {
+		name  string
+		value types.Int64
+		set   func(int)
+	}{
+		{"max_idle_conns", config.MaxIdleConns, c.SetMaxIdleConns},
+		{"max_idle_conns_per_host", config.MaxIdleConnsPerHost, c.SetMaxIdleConnsPerHost},
+	} {
+		if setting.value.IsNull() {
+			continue
+		}
+		if setting.value.ValueInt64() < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(setting.name),
+				"Invalid Connection Pool Setting",
+				fmt.Sprintf("%s must not be negative, got %d.", setting.name, setting.value.ValueInt64()),
+			)
+			return
+		}
+		setting.set(int(setting.value.ValueInt64()))
+	}
+	if d, ok := parseDurationAttribute(config.IdleConnTimeout, "idle_conn_timeout", &resp.Diagnostics); ok {
+		c.SetIdleConnTimeout(d)
+	}
+
+	// Get dry run flag from config or environment variable
+	if v := os.Getenv("CODERABBIT_DRY_RUN"); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid CODERABBIT_DRY_RUN Value",
+				fmt.Sprintf("CODERABBIT_DRY_RUN must be a boolean, got %q.", v),
+			)
+			return
+		}
+		c.DryRun = parsed
+	}
+	if !config.DryRun.IsNull() {
+		c.DryRun = config.DryRun.ValueBool()
+	}
+
+	if c.DryRun {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("dry_run"),
+			"Dry Run Enabled",
+			"Seat assign and unassign calls are logged but not sent to the CodeRabbit API. "+
+				"No seats will be changed, although Terraform state will record the changes as applied.",
+		)
+	}
+
+	if !config.DisableCache.IsNull() {
+		c.DisableCache = config.DisableCache.ValueBool()
+	}
+
+	// Token scopes are checked lazily by the features that need them
+	c.ValidateGitHubScopes = config.ValidateGitHubScopes.IsNull() || config.ValidateGitHubScopes.ValueBool()
+
+	if d, ok := parseDurationAttribute(config.RequestTimeout, "request_timeout", &resp.Diagnostics); ok {
+		c.SetRequestTimeout(d)
+	}
+	if d, ok := parseDurationAttribute(config.SeatsReadTimeout, "seats_read_timeout", &resp.Diagnostics); ok {
+		c.SeatsReadTimeout = d
+	}
+	if d, ok := parseDurationAttribute(config.SeatsWriteTimeout, "seats_write_timeout", &resp.Diagnostics); ok {
+		c.SeatsWriteTimeout = d
+	}
+
+	if !config.MaxResponseBytes.IsNull() {
+		if config.MaxResponseBytes.ValueInt64() < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_response_bytes"),
+				"Invalid Max Response Bytes",
+				fmt.Sprintf("max_response_bytes must not be negative, got %d.", config.MaxResponseBytes.ValueInt64()),
+			)
+			return
+		}
+		c.MaxResponseBytes = config.MaxResponseBytes.ValueInt64()
+	}
+
+	// Get retry settings from config, falling back to the client defaults
+	if !config.MaxRetries.IsNull() {
+		if config.MaxRetries.ValueInt64() < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_retries"),
+				"Invalid Max Retries",
+				fmt.Sprintf("max_retries must not be negative, got %d.", config.MaxRetries.ValueInt64()),
+			)
+			return
+		}
+		c.RetryConfig.MaxRetries = int(config.MaxRetries.ValueInt64())
+	}
+	if !config.RetryBudget.IsNull() {
+		if config.RetryBudget.ValueInt64() < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("retry_budget"),
+				"Invalid Retry Budget",
+				fmt.Sprintf("retry_budget must not be negative, got %d.", config.RetryBudget.ValueInt64()),
+			)
+			return
+		}
+		c.RetryConfig.Budget = int(config.RetryBudget.ValueInt64())
+	}
+	if d, ok := parseDurationAttribute(config.RetryBaseDelay, "retry_base_delay", &resp.Diagnostics); ok {
+		c.RetryConfig.BaseDelay = d
+	}
+	if d, ok := parseDurationAttribute(config.RetryMaxDelay, "retry_max_delay", &resp.Diagnostics); ok {
+		c.RetryConfig.MaxDelay = d
+	}
+	if !config.RetryBackoff.IsNull() {
+		strategy := config.RetryBackoff.ValueString()
+		if !slices.Contains(client.BackoffStrategies, strategy) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("retry_backoff"),
+				"Invalid Retry Backoff",
+				fmt.Sprintf("retry_backoff must be one of %s, got %q.", strings.Join(client.BackoffStrategies, ", "), strategy),
+			)
+			return
+		}
+		c.RetryConfig.Strategy = strategy
+	}
+	if d, ok := parseDurationAttribute(config.RetryMaxElapsed, "retry_max_elapsed", &resp.Diagnostics); ok {
+		c.RetryConfig.MaxElapsed = d
+	}
+	if !config.RequestsPerSecond.IsNull() {
+		if config.RequestsPerSecond.ValueFloat64() < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("requests_per_second"),
+				"Invalid Requests Per Second",
+				fmt.Sprintf("requests_per_second must not be negative, got %g.", config.RequestsPerSecond.ValueFloat64()),
+			)
+			return
+		}
+		c.RetryConfig.RequestsPerSecond = config.RequestsPerSecond.ValueFloat64()
+	}
+	if !config.CircuitBreakerThreshold.IsNull() {
+		if config.CircuitBreakerThreshold.ValueInt64() < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("circuit_breaker_threshold"),
+				"Invalid Circuit Breaker Threshold",
+				fmt.Sprintf("circuit_breaker_threshold must not be negative, got %d.", config.CircuitBreakerThreshold.ValueInt64()),
+			)
+			return
+		}
+		c.RetryConfig.CircuitBreakerThreshold = int(config.CircuitBreakerThreshold.ValueInt64())
+	}
+	if d, ok := parseDurationAttribute(config.CircuitBreakerWindow, "circuit_breaker_window", &resp.Diagnostics); ok {
+		c.RetryConfig.CircuitBreakerWindow = d
+	}
+	if d, ok := parseDurationAttribute(config.CircuitBreakerCooldown, "circuit_breaker_cooldown", &resp.Diagnostics); ok {
+		c.RetryConfig.CircuitBreakerCooldown = d
+	}
+	if d, ok := parseDurationAttribute(config.SeatWaitTimeout, "seat_wait_timeout", &resp.Diagnostics); ok {
+		c.SeatWaitTimeout = d
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if c.RetryConfig.BaseDelay > c.RetryConfig.MaxDelay {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_base_delay"),
+			"Invalid Retry Delay",
+			fmt.Sprintf("retry_base_delay (%s) must not be greater than retry_max_delay (%s).", c.RetryConfig.BaseDelay, c.RetryConfig.MaxDelay),
+		)
+		return
+	}
+
+	if config.ValidateCredentials.IsNull() || config.ValidateCredentials.ValueBool() {
+		if err := c.Ping(ctx); err != nil {
+			var apiErr *client.APIError
+			switch {
+			case client.IsUnauthorized(err) && errors.As(err, &apiErr):
+				resp.Diagnostics.AddError(
+					fmt.Sprintf("CodeRabbit API Key Rejected (%d)", apiErr.StatusCode),
+					fmt.Sprintf("The CodeRabbit API rejected the configured API key. Check api_key, api_key_file or CODERABBITAI_API_KEY: %s", err.Error()),
+				)
+				return
+			case errors.As(err, &apiErr):
+				resp.Diagnostics.AddError(
+					"Unable to Validate CodeRabbit API Key",
+					fmt.Sprintf("The CodeRabbit API at %s returned an error while validating the API key. Set validate_credentials = false to skip this check: %s", c.BaseURL, err.Error()),
+				)
+				return
+			}
+			resp.Diagnostics.AddError(
+				"Unable to Reach CodeRabbit API",
+				fmt.Sprintf("Could not validate the API key because the CodeRabbit API at %s could not be reached. Check base_url and network access, or set validate_credentials = false to skip this check: %s", c.BaseURL, err.Error()),
+			)
+			return
+		}
+	}
 
 	// Make the client available to resources and data sources
 	resp.DataSourceData = c
 	resp.ResourceData = c
 }
 
+// oidcSubjectToken returns a function reading the OIDC token to exchange,
+// from tokenFile when set or else from the workload identity environment
+// variables. It returns false, adding an error, when no token is available.
+func oidcSubjectToken(tokenFile types.String, diags *diag.Diagnostics) (func() (string, error), bool) {
+	if !tokenFile.IsNull() && tokenFile.ValueString() != "" {
+		name := tokenFile.ValueString()
+		if _, err := os.Stat(name); err != nil {
+			diags.AddAttributeError(
+				path.Root("oidc_token_file"),
+				"Unable to Read OIDC Token File",
+				fmt.Sprintf("Could not read OIDC token file: %s", err.Error()),
+			)
+			return nil, false
+		}
+		return func() (string, error) {
+			data, err := os.ReadFile(name)
+			if err != nil {
+				return "", err
+			}
+			return strings.TrimSpace(string(data)), nil
+		}, true
+	}
+
+	for _, name := range []string{"TF_WORKLOAD_IDENTITY_TOKEN", "TFC_WORKLOAD_IDENTITY_TOKEN"} {
+		if token := os.Getenv(name); token != "" {
+			return func() (string, error) { return token, nil }, true
+		}
+	}
+
+	diags.AddAttributeError(
+		path.Root("oidc_token_file"),
+		"Missing OIDC Token",
+		"oidc_token_endpoint is set but no OIDC token is available. "+
+			"Set oidc_token_file, or the TF_WORKLOAD_IDENTITY_TOKEN or TFC_WORKLOAD_IDENTITY_TOKEN environment variable.",
+	)
+	return nil, false
+}
+
+// setEndpointPath overrides *dst with a configured endpoint path, adding the
+// leading slash if it is missing. Unset or empty values keep the default.
+func setEndpointPath(dst *string, value types.String) {
+	if value.IsNull() || value.IsUnknown() || value.ValueString() == "" {
+		return
+	}
+	*dst = "/" + strings.TrimLeft(value.ValueString(), "/")
+}
+
+// normalizeBaseURL checks that value is an absolute http or https URL with a
+// host and trims trailing slashes, so that the API path can be appended to it
+// directly. It adds an attribute error and returns false otherwise.
+func normalizeBaseURL(value, name string, diags *diag.Diagnostics) (string, bool) {
+	u, err := url.Parse(value)
+	switch {
+	case err != nil:
+		diags.AddAttributeError(
+			path.Root(name),
+			"Invalid Base URL",
+			fmt.Sprintf("%s must be a URL such as https://api.coderabbit.ai: %s", name, err.Error()),
+		)
+		return "", false
+	case u.Scheme != "http" && u.Scheme != "https":
+		diags.AddAttributeError(
+			path.Root(name),
+			"Invalid Base URL",
+			fmt.Sprintf("%s must start with http:// or https://, e.g. https://api.coderabbit.ai, got %q.", name, value),
+		)
+		return "", false
+	case u.Host == "":
+		diags.AddAttributeError(
+			path.Root(name),
+			"Invalid Base URL",
+			fmt.Sprintf("%s must include a host, e.g. https://api.coderabbit.ai, got %q.", name, value),
+		)
+		return "", false
+	case u.RawQuery != "" || u.Fragment != "":
+		diags.AddAttributeError(
+			path.Root(name),
+			"Invalid Base URL",
+			fmt.Sprintf("%s must not include a query or fragment, got %q.", name, value),
+		)
+		return "", false
+	}
+
+	return strings.TrimRight(value, "/"), true
+}
+
+// parseDurationAttribute parses a non-negative Go duration string attribute.
+// It returns false when the attribute is unset or invalid, adding an
+// attribute error in the latter case.
+func parseDurationAttribute(value types.String, name string, diags *diag.Diagnostics) (time.Duration, bool) {
+	if value.IsNull() || value.IsUnknown() {
+		return 0, false
+	}
+
+	d, err := time.ParseDuration(value.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root(name),
+			"Invalid Duration",
+			fmt.Sprintf("%s must be a duration such as 500ms or 30s: %s", name, err.Error()),
+		)
+		return 0, false
+	}
+	if d < 0 {
+		diags.AddAttributeError(
+			path.Root(name),
+			"Invalid Duration",
+			fmt.Sprintf("%s must not be negative, got %s.", name, d),
+		)
+		return 0, false
+	}
+
+	return d, true
+}
+
 func (p *CodeRabbitProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		resources.NewSeatsResource,
+		resources.NewSeatsBulkResource,
+		resources.NewTeamSeatsResource,
 	}
 }
 
 func (p *CodeRabbitProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		resources.NewSeatsDataSource,
+		resources.NewSeatsReportDataSource,
+		resources.NewSeatsImportDataSource,
+		resources.NewSeatDataSource,
+		resources.NewSeatByIDDataSource,
+		resources.NewSubscriptionDataSource,
+		resources.NewRepositoriesDataSource,
+		resources.NewSeatReaperDataSource,
+		resources.NewPingDataSource,
 	}
 }
@@ -0,0 +1,426 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/coderabbitai/terraform-provider-coderabbit/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// providerEnv lists the environment variables read by Configure
+var providerEnv = []string{
+	"CODERABBITAI_API_KEY",
+	"CODERABBIT_BASE_URL",
+	"CODERABBIT_FALLBACK_BASE_URL",
+	"CODERABBIT_OIDC_TOKEN_ENDPOINT",
+	"CODERABBIT_API_VERSION",
+	"CODERABBIT_GITHUB_TOKEN",
+	"CODERABBIT_INSECURE",
+	"CODERABBIT_DRY_RUN",
+	"GITHUB_TOKEN",
+	"GH_TOKEN",
+	"GITHUB_BASE_URL",
+	"GITLAB_TOKEN",
+	"GITLAB_BASE_URL",
+	"BITBUCKET_TOKEN",
+	"BITBUCKET_BASE_URL",
+	"TF_WORKLOAD_IDENTITY_TOKEN",
+	"TFC_WORKLOAD_IDENTITY_TOKEN",
+}
+
+// clearProviderEnv unsets the provider's environment variables for the test.
+// t.Setenv restores them afterwards; unsetting matters for variables whose
+// empty value means something, like CODERABBIT_API_VERSION.
+func clearProviderEnv(t *testing.T) {
+	t.Helper()
+	for _, name := range providerEnv {
+		t.Setenv(name, "")
+		os.Unsetenv(name)
+	}
+}
+
+// providerConfig builds a provider configuration from the given attribute
+// values; every other attribute is null
+func providerConfig(t *testing.T, attrs map[string]tftypes.Value) tfsdk.Config {
+	t.Helper()
+
+	ctx := context.Background()
+	var schemaResp provider.SchemaResponse
+	(&CodeRabbitProvider{}).Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+
+	objType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := make(map[string]tftypes.Value, len(objType.AttributeTypes))
+	for name, attrType := range objType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	for name, value := range attrs {
+		if _, ok := values[name]; !ok {
+			t.Fatalf("unknown provider attribute %q", name)
+		}
+		values[name] = value
+	}
+
+	return tfsdk.Config{
+		Raw:    tftypes.NewValue(objType, values),
+		Schema: schemaResp.Schema,
+	}
+}
+
+// configureProvider runs Configure with the given attributes and no provider
+// environment variables set. See configureWithEnv.
+func configureProvider(t *testing.T, attrs map[string]tftypes.Value) (*client.Client, diag.Diagnostics) {
+	t.Helper()
+	clearProviderEnv(t)
+	return configureWithEnv(t, attrs)
+}
+
+// configureWithEnv runs Configure with the given attributes, on top of an
+// API key and with the credential and scope checks disabled unless attrs
+// sets them, keeping the current environment. It returns the configured
+// client, or nil on error.
+func configureWithEnv(t *testing.T, attrs map[string]tftypes.Value) (*client.Client, diag.Diagnostics) {
+	t.Helper()
+
+	withDefaults := map[string]tftypes.Value{
+		"api_key":                tftypes.NewValue(tftypes.String, "test-api-key"),
+		"validate_credentials":   tftypes.NewValue(tftypes.Bool, false),
+		"validate_github_scopes": tftypes.NewValue(tftypes.Bool, false),
+	}
+	for name, value := range attrs {
+		withDefaults[name] = value
+	}
+
+	var resp provider.ConfigureResponse
+	p := &CodeRabbitProvider{version: "test"}
+	p.Configure(context.Background(), provider.ConfigureRequest{Config: providerConfig(t, withDefaults)}, &resp)
+
+	c, _ := resp.ResourceData.(*client.Client)
+	if c != nil {
+		t.Cleanup(c.Close)
+	}
+	return c, resp.Diagnostics
+}
+
+func TestConfigureDefaults(t *testing.T) {
+	c, diags := configureProvider(t, nil)
+	if diags.HasError() {
+		t.Fatalf("Configure() diagnostics = %v", diags)
+	}
+
+	if c.APIKey != "test-api-key" {
+		t.Errorf("APIKey = %q, want test-api-key", c.APIKey)
+	}
+	if c.BaseURL != "https://api.coderabbit.ai" {
+		t.Errorf("BaseURL = %q, want https://api.coderabbit.ai", c.BaseURL)
+	}
+	if c.HTTPClient.Timeout != client.DefaultRequestTimeout {
+		t.Errorf("HTTPClient.Timeout = %s, want %s", c.HTTPClient.Timeout, client.DefaultRequestTimeout)
+	}
+	if c.RetryConfig.CircuitBreakerThreshold != 0 {
+		t.Errorf("CircuitBreakerThreshold = %d, want 0 (disabled)", c.RetryConfig.CircuitBreakerThreshold)
+	}
+}
+
+func TestConfigureMissingAPIKey(t *testing.T) {
+	_, diags := configureProvider(t, map[string]tftypes.Value{
+		"api_key": tftypes.NewValue(tftypes.String, nil),
+	})
+	if !diags.HasError() {
+		t.Fatal("Configure() without an API key succeeded, want an error")
+	}
+}
+
+func TestConfigureCircuitBreaker(t *testing.T) {
+	c, diags := configureProvider(t, map[string]tftypes.Value{
+		"circuit_breaker_threshold": tftypes.NewValue(tftypes.Number, 5),
+		"circuit_breaker_window":    tftypes.NewValue(tftypes.String, "2m"),
+		"circuit_breaker_cooldown":  tftypes.NewValue(tftypes.String, "10s"),
+	})
+	if diags.HasError() {
+		t.Fatalf("Configure() diagnostics = %v", diags)
+	}
+
+	if c.RetryConfig.CircuitBreakerThreshold != 5 {
+		t.Errorf("CircuitBreakerThreshold = %d, want 5", c.RetryConfig.CircuitBreakerThreshold)
+	}
+	if c.RetryConfig.CircuitBreakerWindow != 2*time.Minute {
+		t.Errorf("CircuitBreakerWindow = %s, want 2m", c.RetryConfig.CircuitBreakerWindow)
+	}
+	if c.RetryConfig.CircuitBreakerCooldown != 10*time.Second {
+		t.Errorf("CircuitBreakerCooldown = %s, want 10s", c.RetryConfig.CircuitBreakerCooldown)
+	}
+}
+
+func TestConfigureInvalidCircuitBreaker(t *testing.T) {
+	for name, value := range map[string]tftypes.Value{
+		"circuit_breaker_threshold": tftypes.NewValue(tftypes.Number, -1),
+		"circuit_breaker_window":    tftypes.NewValue(tftypes.String, "soon"),
+		"circuit_breaker_cooldown":  tftypes.NewValue(tftypes.String, "-1s"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, diags := configureProvider(t, map[string]tftypes.Value{name: value})
+			if !diags.HasError() {
+				t.Fatalf("Configure() with invalid %s succeeded, want an error", name)
+			}
+		})
+	}
+}
+
+func TestConfigureRequestsPerSecond(t *testing.T) {
+	c, diags := configureProvider(t, map[string]tftypes.Value{
+		"requests_per_second": tftypes.NewValue(tftypes.Number, 2.5),
+	})
+	if diags.HasError() {
+		t.Fatalf("Configure() diagnostics = %v", diags)
+	}
+	if c.RetryConfig.RequestsPerSecond != 2.5 {
+		t.Errorf("RequestsPerSecond = %g, want 2.5", c.RetryConfig.RequestsPerSecond)
+	}
+
+	_, diags = configureProvider(t, map[string]tftypes.Value{
+		"requests_per_second": tftypes.NewValue(tftypes.Number, -1),
+	})
+	if !diags.HasError() {
+		t.Error("Configure() with negative requests_per_second succeeded, want an error")
+	}
+}
+
+func TestConfigureDefersGitHubScopeCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Configure() made a GitHub request to %s, want scopes checked only by features needing them", r.URL)
+	}))
+	defer server.Close()
+
+	c, diags := configureProvider(t, map[string]tftypes.Value{
+		"github_token":           tftypes.NewValue(tftypes.String, "ghp_test"),
+		"github_base_url":        tftypes.NewValue(tftypes.String, server.URL+"/api/v3"),
+		"validate_github_scopes": tftypes.NewValue(tftypes.Bool, nil),
+	})
+	if diags.HasError() || diags.WarningsCount() > 0 {
+		t.Fatalf("Configure() diagnostics = %v", diags)
+	}
+	if !c.ValidateGitHubScopes {
+		t.Error("ValidateGitHubScopes = false, want true by default")
+	}
+}
+
+func TestConfigureGitHubTokenReachesGitHub(t *testing.T) {
+	tests := []struct {
+		name  string
+		attrs map[string]tftypes.Value
+		env   map[string]string
+	}{
+		{"attribute", map[string]tftypes.Value{"github_token": tftypes.NewValue(tftypes.String, "ghp_test")}, nil},
+		{"CODERABBIT_GITHUB_TOKEN", nil, map[string]string{"CODERABBIT_GITHUB_TOKEN": "ghp_test"}},
+		{"GITHUB_TOKEN", nil, map[string]string{"GITHUB_TOKEN": "ghp_test"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var auth string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				auth = r.Header.Get("Authorization")
+				_, _ = w.Write([]byte(`{"id":583231,"login":"octocat"}`))
+			}))
+			defer server.Close()
+
+			clearProviderEnv(t)
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+			attrs := map[string]tftypes.Value{"github_base_url": tftypes.NewValue(tftypes.String, server.URL+"/api/v3")}
+			for name, value := range tt.attrs {
+				attrs[name] = value
+			}
+
+			c, diags := configureWithEnv(t, attrs)
+			if diags.HasError() {
+				t.Fatalf("Configure() diagnostics = %v", diags)
+			}
+			if _, err := c.GetGitUserID(context.Background(), "octocat"); err != nil {
+				t.Fatalf("GetGitUserID() error = %v", err)
+			}
+			if auth != "Bearer ghp_test" {
+				t.Errorf("GitHub Authorization header = %q, want %q", auth, "Bearer ghp_test")
+			}
+		})
+	}
+}
+
+func TestConfigureAPIVersion(t *testing.T) {
+	tests := []struct {
+		name  string
+		attrs map[string]tftypes.Value
+		env   map[string]string
+		want  string
+	}{
+		{"default", nil, nil, "v1"},
+		{"v2", map[string]tftypes.Value{"api_version": tftypes.NewValue(tftypes.String, "v2")}, nil, "v2"},
+		{"slashes trimmed", map[string]tftypes.Value{"api_version": tftypes.NewValue(tftypes.String, "/v2/")}, nil, "v2"},
+		{"empty omits prefix", map[string]tftypes.Value{"api_version": tftypes.NewValue(tftypes.String, "")}, nil, ""},
+		{"environment", nil, map[string]string{"CODERABBIT_API_VERSION": "v3"}, "v3"},
+		{"empty environment omits prefix", nil, map[string]string{"CODERABBIT_API_VERSION": ""}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearProviderEnv(t)
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			c, diags := configureWithEnv(t, tt.attrs)
+			if diags.HasError() {
+				t.Fatalf("Configure() diagnostics = %v", diags)
+			}
+			if c.APIVersion != tt.want {
+				t.Errorf("APIVersion = %q, want %q", c.APIVersion, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigureRequestTimeout(t *testing.T) {
+	tests := []struct {
+		name  string
+		value tftypes.Value
+		want  time.Duration
+	}{
+		{"default", tftypes.NewValue(tftypes.String, nil), client.DefaultRequestTimeout},
+		{"set", tftypes.NewValue(tftypes.String, "90s"), 90 * time.Second},
+		{"zero disables the client timeout", tftypes.NewValue(tftypes.String, "0"), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, diags := configureProvider(t, map[string]tftypes.Value{"request_timeout": tt.value})
+			if diags.HasError() {
+				t.Fatalf("Configure() diagnostics = %v", diags)
+			}
+			if c.HTTPClient.Timeout != tt.want {
+				t.Errorf("HTTPClient.Timeout = %s, want %s", c.HTTPClient.Timeout, tt.want)
+			}
+			if c.GitHubHTTPClient.Timeout != tt.want {
+				t.Errorf("GitHubHTTPClient.Timeout = %s, want %s", c.GitHubHTTPClient.Timeout, tt.want)
+			}
+		})
+	}
+
+	for _, value := range []string{"soon", "-1s"} {
+		_, diags := configureProvider(t, map[string]tftypes.Value{"request_timeout": tftypes.NewValue(tftypes.String, value)})
+		if !diags.HasError() {
+			t.Errorf("Configure() with request_timeout %q succeeded, want an error", value)
+		}
+	}
+}
+
+func TestConfigureDefaultHeaders(t *testing.T) {
+	headers := func(values map[string]string) tftypes.Value {
+		elems := make(map[string]tftypes.Value, len(values))
+		for name, value := range values {
+			elems[name] = tftypes.NewValue(tftypes.String, value)
+		}
+		return tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, elems)
+	}
+
+	c, diags := configureProvider(t, map[string]tftypes.Value{
+		"default_headers": headers(map[string]string{"X-Corp-Env": "prod"}),
+	})
+	if diags.HasError() {
+		t.Fatalf("Configure() diagnostics = %v", diags)
+	}
+	if got := c.DefaultHeaders["X-Corp-Env"]; got != "prod" {
+		t.Errorf("DefaultHeaders[X-Corp-Env] = %q, want prod", got)
+	}
+
+	for _, name := range []string{"x-coderabbitai-api-key", "Authorization"} {
+		_, diags := configureProvider(t, map[string]tftypes.Value{
+			"default_headers": headers(map[string]string{name: "value"}),
+		})
+		if !diags.HasError() {
+			t.Errorf("Configure() with reserved default header %s succeeded, want an error", name)
+		}
+	}
+}
+
+func TestConfigureSeatEndpointPaths(t *testing.T) {
+	c, diags := configureProvider(t, nil)
+	if diags.HasError() {
+		t.Fatalf("Configure() diagnostics = %v", diags)
+	}
+	if c.SeatsPath != client.DefaultSeatsPath || c.AssignSeatPath != client.DefaultAssignSeatPath || c.UnassignSeatPath != client.DefaultUnassignSeatPath {
+		t.Errorf("default paths = %q, %q, %q, want the client defaults", c.SeatsPath, c.AssignSeatPath, c.UnassignSeatPath)
+	}
+
+	c, diags = configureProvider(t, map[string]tftypes.Value{
+		"seats_path":         tftypes.NewValue(tftypes.String, "orgs/acme/seats"),
+		"assign_seat_path":   tftypes.NewValue(tftypes.String, "/orgs/acme/seats/add"),
+		"unassign_seat_path": tftypes.NewValue(tftypes.String, ""),
+	})
+	if diags.HasError() {
+		t.Fatalf("Configure() diagnostics = %v", diags)
+	}
+	if c.SeatsPath != "/orgs/acme/seats" {
+		t.Errorf("SeatsPath = %q, want /orgs/acme/seats", c.SeatsPath)
+	}
+	if c.AssignSeatPath != "/orgs/acme/seats/add" {
+		t.Errorf("AssignSeatPath = %q, want /orgs/acme/seats/add", c.AssignSeatPath)
+	}
+	if c.UnassignSeatPath != client.DefaultUnassignSeatPath {
+		t.Errorf("UnassignSeatPath = %q, want the default for an empty value", c.UnassignSeatPath)
+	}
+}
+
+func TestConfigureBaseURL(t *testing.T) {
+	for value, want := range map[string]string{
+		"https://api.coderabbit.ai":         "https://api.coderabbit.ai",
+		"https://api.coderabbit.ai/":        "https://api.coderabbit.ai",
+		"http://localhost:8080/coderabbit/": "http://localhost:8080/coderabbit",
+	} {
+		c, diags := configureProvider(t, map[string]tftypes.Value{"base_url": tftypes.NewValue(tftypes.String, value)})
+		if diags.HasError() {
+			t.Fatalf("Configure() with base_url %q diagnostics = %v", value, diags)
+		}
+		if c.BaseURL != want {
+			t.Errorf("BaseURL for base_url %q = %q, want %q", value, c.BaseURL, want)
+		}
+	}
+}
+
+func TestConfigureInvalidBaseURL(t *testing.T) {
+	for _, value := range []string{
+		"api.coderabbit.ai",
+		"ftp://api.coderabbit.ai",
+		"https://",
+		"https://api.coderabbit.ai/?org=acme",
+		"https://api.coderabbit.ai/#v1",
+		"http://[::1",
+	} {
+		t.Run(value, func(t *testing.T) {
+			_, diags := configureProvider(t, map[string]tftypes.Value{"base_url": tftypes.NewValue(tftypes.String, value)})
+			if !diags.HasError() {
+				t.Fatalf("Configure() with base_url %q succeeded, want an error", value)
+			}
+			for _, d := range diags.Errors() {
+				withPath, ok := d.(diag.DiagnosticWithPath)
+				if !ok || !withPath.Path().Equal(path.Root("base_url")) {
+					t.Errorf("error %q doesn't point at base_url", d.Summary())
+				}
+			}
+		})
+	}
+
+	_, diags := configureProvider(t, map[string]tftypes.Value{"fallback_base_url": tftypes.NewValue(tftypes.String, "backup.coderabbit.ai")})
+	if !diags.HasError() {
+		t.Error("Configure() with an invalid fallback_base_url succeeded, want an error")
+	}
+}
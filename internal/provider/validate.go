@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+)
+
+var _ provider.ProviderWithValidateConfig = &CodeRabbitProvider{}
+
+// ValidateConfig rejects conflicting or incomplete combinations of provider
+// attributes at plan time. Only values written in the configuration are
+// checked; settings taken from environment variables are validated by
+// Configure, and unknown values are skipped until they are known.
+func (p *CodeRabbitProvider) ValidateConfig(ctx context.Context, req provider.ValidateConfigRequest, resp *provider.ValidateConfigResponse) {
+	var config CodeRabbitProviderModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if isSet(config.APIKey) && isSet(config.APIKeyFile) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_key_file"),
+			"Conflicting API Key Settings",
+			"api_key and api_key_file cannot both be set. Remove one of them.",
+		)
+	}
+
+	if isSet(config.OIDCTokenEndpoint) {
+		for _, setting := range []struct {
+			name  string
+			value attr.Value
+		}{
+			{"api_key", config.APIKey},
+			{"api_key_file", config.APIKeyFile},
+		} {
+			if isSet(setting.value) {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(setting.name),
+					"Conflicting CodeRabbit Authentication",
+					fmt.Sprintf("oidc_token_endpoint cannot be combined with %s. Remove one of them.", setting.name),
+				)
+			}
+		}
+	}
+
+	// GitHub App settings only work together and replace github_token
+	var appSet, appMissing []string
+	for _, setting := range []struct {
+		name  string
+		value attr.Value
+	}{
+		{"github_app_id", config.GitHubAppID},
+		{"github_app_installation_id", config.GitHubAppInstallationID},
+		{"github_app_private_key_file", config.GitHubAppPrivateKeyFile},
+	} {
+		switch {
+		case setting.value.IsUnknown():
+			// An unknown value may still be set, so it counts as neither
+		case setting.value.IsNull():
+			appMissing = append(appMissing, setting.name)
+		default:
+			appSet = append(appSet, setting.name)
+		}
+	}
+	if len(appSet) > 0 {
+		for _, name := range appMissing {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"Incomplete GitHub App Configuration",
+				fmt.Sprintf("%s must be set because %s is set. github_app_id, github_app_installation_id and github_app_private_key_file must all be set to authenticate as a GitHub App.", name, strings.Join(appSet, ", ")),
+			)
+		}
+		if isSet(config.GitHubToken) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("github_token"),
+				"Conflicting GitHub Authentication",
+				"github_token cannot be combined with GitHub App authentication (github_app_*). Remove github_token or the GitHub App settings.",
+			)
+		}
+	}
+
+	if isSet(config.CACertFile) && isSet(config.Insecure) && config.Insecure.ValueBool() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("ca_cert_file"),
+			"CA Certificate Ignored for CodeRabbit Requests",
+			"insecure = true skips certificate verification for CodeRabbit API requests, so ca_cert_file only applies to GitHub requests.",
+		)
+	}
+}
+
+// isSet reports whether a configuration value is known and not null
+func isSet(value attr.Value) bool {
+	return !value.IsNull() && !value.IsUnknown()
+}
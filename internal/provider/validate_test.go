@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestValidateConfig(t *testing.T) {
+	str := func(s string) tftypes.Value { return tftypes.NewValue(tftypes.String, s) }
+	num := func(n int64) tftypes.Value { return tftypes.NewValue(tftypes.Number, n) }
+	unknown := tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	app := map[string]tftypes.Value{
+		"github_app_id":               num(1),
+		"github_app_installation_id":  num(2),
+		"github_app_private_key_file": str("key.pem"),
+	}
+	copyAttrs := func(base map[string]tftypes.Value) map[string]tftypes.Value {
+		attrs := make(map[string]tftypes.Value, len(base)+1)
+		for name, value := range base {
+			attrs[name] = value
+		}
+		return attrs
+	}
+	with := func(base map[string]tftypes.Value, name string, value tftypes.Value) map[string]tftypes.Value {
+		attrs := copyAttrs(base)
+		attrs[name] = value
+		return attrs
+	}
+	without := func(base map[string]tftypes.Value, name string) map[string]tftypes.Value {
+		attrs := copyAttrs(base)
+		delete(attrs, name)
+		return attrs
+	}
+
+	tests := []struct {
+		name        string
+		attrs       map[string]tftypes.Value
+		wantErrors  []string
+		wantWarning string
+	}{
+		{name: "empty", attrs: nil},
+		{name: "api_key", attrs: map[string]tftypes.Value{"api_key": str("key")}},
+		{name: "api_key and api_key_file", attrs: map[string]tftypes.Value{"api_key": str("key"), "api_key_file": str("key.txt")}, wantErrors: []string{"api_key_file"}},
+		{name: "api_key_file unknown", attrs: map[string]tftypes.Value{"api_key": str("key"), "api_key_file": unknown}},
+		{name: "oidc with api_key", attrs: map[string]tftypes.Value{"oidc_token_endpoint": str("https://example.com/token"), "api_key": str("key")}, wantErrors: []string{"api_key"}},
+		{name: "oidc with api_key_file", attrs: map[string]tftypes.Value{"oidc_token_endpoint": str("https://example.com/token"), "api_key_file": str("key.txt")}, wantErrors: []string{"api_key_file"}},
+		{name: "github app", attrs: app},
+		{name: "github app missing private key", attrs: without(app, "github_app_private_key_file"), wantErrors: []string{"github_app_private_key_file"}},
+		{name: "github app id only", attrs: map[string]tftypes.Value{"github_app_id": num(1)}, wantErrors: []string{"github_app_installation_id", "github_app_private_key_file"}},
+		{name: "github app with unknown installation", attrs: with(app, "github_app_installation_id", tftypes.NewValue(tftypes.Number, tftypes.UnknownValue))},
+		{name: "github app with github_token", attrs: with(app, "github_token", str("ghp_test")), wantErrors: []string{"github_token"}},
+		{name: "insecure with ca_cert_file", attrs: map[string]tftypes.Value{"insecure": tftypes.NewValue(tftypes.Bool, true), "ca_cert_file": str("ca.pem")}, wantWarning: "ca_cert_file"},
+		{name: "secure with ca_cert_file", attrs: map[string]tftypes.Value{"insecure": tftypes.NewValue(tftypes.Bool, false), "ca_cert_file": str("ca.pem")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp provider.ValidateConfigResponse
+			(&CodeRabbitProvider{}).ValidateConfig(context.Background(), provider.ValidateConfigRequest{Config: providerConfig(t, tt.attrs)}, &resp)
+
+			errs := resp.Diagnostics.Errors()
+			if len(errs) != len(tt.wantErrors) {
+				t.Fatalf("ValidateConfig() errors = %v, want errors on %v", errs, tt.wantErrors)
+			}
+			for _, name := range tt.wantErrors {
+				if !hasDiagnosticAt(resp, name, true) {
+					t.Errorf("ValidateConfig() errors = %v, want one on %s", errs, name)
+				}
+			}
+
+			warnings := resp.Diagnostics.Warnings()
+			switch {
+			case tt.wantWarning == "" && len(warnings) > 0:
+				t.Errorf("ValidateConfig() warnings = %v, want none", warnings)
+			case tt.wantWarning != "" && !hasDiagnosticAt(resp, tt.wantWarning, false):
+				t.Errorf("ValidateConfig() warnings = %v, want one on %s", warnings, tt.wantWarning)
+			}
+		})
+	}
+}
+
+// hasDiagnosticAt reports whether resp has an error, or a warning, on the
+// root attribute name
+func hasDiagnosticAt(resp provider.ValidateConfigResponse, name string, isError bool) bool {
+	diags := resp.Diagnostics.Warnings()
+	if isError {
+		diags = resp.Diagnostics.Errors()
+	}
+	for _, d := range diags {
+		if withPath, ok := d.(diag.DiagnosticWithPath); ok && withPath.Path().Equal(path.Root(name)) {
+			return true
+		}
+	}
+	return false
+}
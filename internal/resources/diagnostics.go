@@ -0,0 +1,116 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/coderabbitai/terraform-provider-coderabbit/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// apiErrorHint maps a known CodeRabbit API failure to a remediation hint
+type apiErrorHint struct {
+	statusCodes []int
+	phrases     []string
+	hint        string
+}
+
+// apiErrorHints lists known API failures, most specific first. An empty
+// statusCodes or phrases list matches any status or message.
+var apiErrorHints = []apiErrorHint{
+	{
+		phrases: []string{"seat limit", "no seats available", "no available seats", "maximum number of seats"},
+		hint:    "Your plan's seat limit is reached; free a seat or upgrade your CodeRabbit plan.",
+	},
+	{
+		phrases: []string{"not a member", "not in organization", "not part of the organization", "user not found"},
+		hint:    "The user is not a member of the CodeRabbit organization; add them to the organization on the Git platform and let CodeRabbit sync before assigning a seat.",
+	},
+	{
+		statusCodes: []int{http.StatusUnauthorized},
+		hint:        "The CodeRabbit API key is invalid or expired; check api_key, api_key_file or CODERABBITAI_API_KEY.",
+	},
+	{
+		statusCodes: []int{http.StatusForbidden},
+		hint:        "The CodeRabbit API key is not allowed to manage seats; use an organization admin API key.",
+	},
+	{
+		statusCodes: []int{http.StatusTooManyRequests},
+		hint:        "The CodeRabbit API rate limit was exceeded; retry later, raise max_retries or lower the coderabbit_seats_bulk concurrency.",
+	},
+}
+
+// errorDetail formats err for a diagnostic detail, appending a remediation
+// hint when it is a CodeRabbit API error with a known cause. The raw error
+// is always kept for debugging.
+func errorDetail(err error) string {
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) {
+		return err.Error()
+	}
+
+	if hint := hintFor(apiErr); hint != "" {
+		return err.Error() + "\n\n" + hint
+	}
+	return err.Error()
+}
+
+// hintFor returns the remediation hint of the first known failure matching apiErr
+func hintFor(apiErr *client.APIError) string {
+	message := strings.ToLower(strings.Join(apiErr.Messages, " ") + " " + string(apiErr.Body))
+
+	for _, h := range apiErrorHints {
+		if len(h.statusCodes) > 0 && !containsInt(h.statusCodes, apiErr.StatusCode) {
+			continue
+		}
+		if len(h.phrases) > 0 && !containsAny(message, h.phrases) {
+			continue
+		}
+		return h.hint
+	}
+	return ""
+}
+
+// containsInt reports whether values contains v
+func containsInt(values []int, v int) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// containsAny reports whether s contains any of substrings
+func containsAny(s string, substrings []string) bool {
+	for _, substring := range substrings {
+		if strings.Contains(s, substring) {
+			return true
+		}
+	}
+	return false
+}
+
+// warnMissingGitHubScopes warns when the configured GitHub token lacks any of
+// the scopes a feature needs, described by usage, e.g. "coderabbit_team_seats
+// needs read:org to list team members"
+func warnMissingGitHubScopes(ctx context.Context, c *client.Client, diags *diag.Diagnostics, usage string, scopes ...string) {
+	missing, err := c.MissingGitHubTokenScopes(ctx, scopes...)
+	if err != nil {
+		diags.AddWarning(
+			"Unable to Check GitHub Token Scopes",
+			fmt.Sprintf("Could not read the scopes of the configured GitHub token. Set validate_github_scopes = false in the provider to skip this check: %s", err.Error()),
+		)
+		return
+	}
+	if len(missing) == 0 {
+		return
+	}
+	diags.AddWarning(
+		"GitHub Token Missing Scopes",
+		fmt.Sprintf("The configured GitHub token lacks the %s scope(s): %s. Add the scopes to the token, or set validate_github_scopes = false in the provider to skip this check.", strings.Join(missing, ", "), usage),
+	)
+}
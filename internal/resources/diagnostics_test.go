@@ -0,0 +1,108 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/coderabbitai/terraform-provider-coderabbit/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestErrorDetailHintsOnSuccessFalse(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"success":false,"message":"Seat limit reached for this plan"}`))
+	}))
+	c.RetryConfig.MaxRetries = 0
+
+	err := c.AssignSeat(context.Background(), "12345")
+	if err == nil {
+		t.Fatal("AssignSeat() error = nil, want success:false failure")
+	}
+
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("AssignSeat() error = %T, want an *client.APIError in the chain", err)
+	}
+
+	detail := errorDetail(err)
+	if !strings.Contains(detail, "Seat limit reached for this plan") {
+		t.Errorf("errorDetail() = %q, want the API message", detail)
+	}
+	if !strings.Contains(detail, "seat limit is reached") {
+		t.Errorf("errorDetail() = %q, want the seat limit hint", detail)
+	}
+}
+
+func TestErrorDetail(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantHint string
+	}{
+		{
+			name:     "unauthorized",
+			err:      &client.APIError{StatusCode: http.StatusUnauthorized, Body: []byte("bad key")},
+			wantHint: "API key is invalid or expired",
+		},
+		{
+			name:     "forbidden",
+			err:      &client.APIError{StatusCode: http.StatusForbidden},
+			wantHint: "organization admin API key",
+		},
+		{
+			name:     "not a member",
+			err:      &client.APIError{StatusCode: http.StatusBadRequest, Messages: []string{"User is not a member of the organization"}},
+			wantHint: "not a member of the CodeRabbit organization",
+		},
+		{
+			name: "unknown API error",
+			err:  &client.APIError{StatusCode: http.StatusBadRequest, Messages: []string{"something else"}},
+		},
+		{
+			name: "not an API error",
+			err:  errors.New("seat limit reached"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detail := errorDetail(tt.err)
+			if !strings.HasPrefix(detail, tt.err.Error()) {
+				t.Errorf("errorDetail() = %q, want it to start with the error", detail)
+			}
+			if tt.wantHint == "" && detail != tt.err.Error() {
+				t.Errorf("errorDetail() = %q, want no hint", detail)
+			}
+			if tt.wantHint != "" && !strings.Contains(detail, tt.wantHint) {
+				t.Errorf("errorDetail() = %q, want hint containing %q", detail, tt.wantHint)
+			}
+		})
+	}
+}
+
+func TestWarnMissingGitHubScopes(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-OAuth-Scopes", "repo")
+		_, _ = w.Write([]byte(`{"id":1,"login":"octocat"}`))
+	}))
+	c.GitHubToken = "ghp_test"
+	c.ValidateGitHubScopes = true
+
+	var diags diag.Diagnostics
+	warnMissingGitHubScopes(context.Background(), c, &diags, "coderabbit_team_seats needs read:org to list team members", "read:org")
+	if diags.WarningsCount() != 1 {
+		t.Fatalf("warnMissingGitHubScopes() diagnostics = %v, want one warning", diags)
+	}
+	if detail := diags[0].Detail(); !strings.Contains(detail, "read:org") || !strings.Contains(detail, "coderabbit_team_seats") {
+		t.Errorf("warning detail = %q, want the missing scope and its usage", detail)
+	}
+
+	diags = nil
+	warnMissingGitHubScopes(context.Background(), c, &diags, "repositories", "repo")
+	if len(diags) != 0 {
+		t.Errorf("warnMissingGitHubScopes() with granted scopes diagnostics = %v, want none", diags)
+	}
+}
@@ -0,0 +1,108 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/coderabbitai/terraform-provider-coderabbit/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &PingDataSource{}
+	_ datasource.DataSourceWithConfigure = &PingDataSource{}
+)
+
+// PingDataSource defines the ping data source implementation
+type PingDataSource struct {
+	client *client.Client
+}
+
+// PingDataSourceModel describes the ping data source data model
+type PingDataSourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	OK        types.Bool   `tfsdk:"ok"`
+	LatencyMS types.Int64  `tfsdk:"latency_ms"`
+}
+
+// NewPingDataSource creates a new ping data source
+func NewPingDataSource() datasource.DataSource {
+	return &PingDataSource{}
+}
+
+func (d *PingDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ping"
+}
+
+func (d *PingDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Checks that the CodeRabbit API is reachable with the configured api_key and base_url. A failed check is reported as a warning and sets ok to false, so it can be asserted in a check block before a large apply.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The unique identifier for this data source.",
+				Computed:    true,
+			},
+			"ok": schema.BoolAttribute{
+				Description: "Whether the CodeRabbit API accepted the request.",
+				Computed:    true,
+			},
+			"latency_ms": schema.Int64Attribute{
+				Description: "Round-trip time of the check in milliseconds, including any retries.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *PingDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = c
+}
+
+func (d *PingDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PingDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	start := time.Now()
+	err := d.client.Ping(ctx)
+	latency := time.Since(start)
+
+	switch {
+	case err == nil:
+	case client.IsUnauthorized(err):
+		resp.Diagnostics.AddWarning(
+			"CodeRabbit API Key Rejected",
+			fmt.Sprintf("The CodeRabbit API rejected the configured API key: %s", err.Error()),
+		)
+	default:
+		resp.Diagnostics.AddWarning(
+			"CodeRabbit API Unreachable",
+			fmt.Sprintf("Could not reach the CodeRabbit API at the configured base_url: %s", err.Error()),
+		)
+	}
+
+	data.ID = types.StringValue("ping")
+	data.OK = types.BoolValue(err == nil)
+	data.LatencyMS = types.Int64Value(latency.Milliseconds())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
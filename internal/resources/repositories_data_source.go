@@ -0,0 +1,128 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/coderabbitai/terraform-provider-coderabbit/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &RepositoriesDataSource{}
+	_ datasource.DataSourceWithConfigure = &RepositoriesDataSource{}
+)
+
+// RepositoriesDataSource defines the repositories data source implementation
+type RepositoriesDataSource struct {
+	client *client.Client
+}
+
+// RepositoriesDataSourceModel describes the repositories data source data model
+type RepositoriesDataSourceModel struct {
+	ID           types.String      `tfsdk:"id"`
+	Repositories []RepositoryModel `tfsdk:"repositories"`
+}
+
+// RepositoryModel describes a single repository in the repositories data source
+type RepositoryModel struct {
+	ID       types.String `tfsdk:"id"`
+	Name     types.String `tfsdk:"name"`
+	FullName types.String `tfsdk:"full_name"`
+	Enabled  types.Bool   `tfsdk:"enabled"`
+}
+
+// NewRepositoriesDataSource creates a new repositories data source
+func NewRepositoriesDataSource() datasource.DataSource {
+	return &RepositoriesDataSource{}
+}
+
+func (d *RepositoriesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_repositories"
+}
+
+func (d *RepositoriesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the repositories managed by CodeRabbit.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The unique identifier for this data source.",
+				Computed:    true,
+			},
+			"repositories": schema.ListNestedAttribute{
+				Description: "Repositories known to CodeRabbit.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The CodeRabbit repository ID.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "The repository name, e.g. my-repo.",
+							Computed:    true,
+						},
+						"full_name": schema.StringAttribute{
+							Description: "The repository name including its owner, e.g. my-org/my-repo.",
+							Computed:    true,
+						},
+						"enabled": schema.BoolAttribute{
+							Description: "Whether CodeRabbit reviews the repository.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *RepositoriesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = c
+}
+
+func (d *RepositoriesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RepositoriesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	repositories, err := d.client.GetRepositories(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Repositories",
+			fmt.Sprintf("Could not read repositories: %s", errorDetail(err)),
+		)
+		return
+	}
+
+	data.ID = types.StringValue("repositories")
+	data.Repositories = make([]RepositoryModel, 0, len(repositories.Repositories))
+	for _, repo := range repositories.Repositories {
+		data.Repositories = append(data.Repositories, RepositoryModel{
+			ID:       types.StringValue(repo.ID),
+			Name:     types.StringValue(repo.Name),
+			FullName: types.StringValue(repo.FullName),
+			Enabled:  types.BoolValue(repo.Enabled),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,135 @@
+package resources
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coderabbitai/terraform-provider-coderabbit/internal/client"
+)
+
+// newTestClient returns a client whose CodeRabbit and GitHub APIs are both
+// served by handler, with retries that don't sleep
+func newTestClient(t *testing.T, handler http.Handler) *client.Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	c := client.NewClient("test-api-key", server.URL, "", "test")
+	c.GitHubBaseURL = server.URL
+	c.RetryConfig.BaseDelay = time.Millisecond
+	c.RetryConfig.MaxDelay = time.Millisecond
+	c.RetryConfig.Jitter = false
+	c.SeatWaitTimeout = 0
+	t.Cleanup(c.Close)
+	return c
+}
+
+// fakeAPI serves the CodeRabbit seats endpoints and the GitHub user
+// endpoints from in-memory state
+type fakeAPI struct {
+	mu sync.Mutex
+
+	// logins maps GitHub logins to their numeric user IDs
+	logins map[string]string
+
+	// assigned holds the git_user_ids that have a seat
+	assigned map[string]bool
+
+	// calls records seat mutations in order, e.g. "assign 1"
+	calls []string
+}
+
+// newFakeAPI returns a fake API knowing the given login to ID mapping and
+// seats assigned to the given IDs
+func newFakeAPI(logins map[string]string, assigned ...string) *fakeAPI {
+	f := &fakeAPI{logins: logins, assigned: make(map[string]bool)}
+	for _, id := range assigned {
+		f.assigned[id] = true
+	}
+	return f
+}
+
+// seats returns the sorted git_user_ids that have a seat
+func (f *fakeAPI) seats() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ids := make([]string, 0, len(f.assigned))
+	for id, ok := range f.assigned {
+		if ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// mutations returns the seat mutations made so far
+func (f *fakeAPI) mutations() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.calls...)
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/v1/seats/":
+		type user struct {
+			GitUserID    string `json:"git_user_id"`
+			SeatAssigned bool   `json:"seat_assigned"`
+		}
+		users := []user{}
+		for id, ok := range f.assigned {
+			users = append(users, user{GitUserID: id, SeatAssigned: ok})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"users": users})
+
+	case r.Method == http.MethodPost && (r.URL.Path == "/v1/seats/assign" || r.URL.Path == "/v1/seats/unassign"):
+		var body struct {
+			GitUserID string `json:"git_user_id"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assign := r.URL.Path == "/v1/seats/assign"
+		f.assigned[body.GitUserID] = assign
+		op := "unassign"
+		if assign {
+			op = "assign"
+		}
+		f.calls = append(f.calls, op+" "+body.GitUserID)
+		_, _ = w.Write([]byte(`{"success":true}`))
+
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/users/"):
+		name := strings.TrimPrefix(r.URL.Path, "/users/")
+		for login, id := range f.logins {
+			if strings.EqualFold(login, name) {
+				fmt.Fprintf(w, `{"id":%s,"login":%q}`, id, login)
+				return
+			}
+		}
+		http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/user/"):
+		id := strings.TrimPrefix(r.URL.Path, "/user/")
+		for login, loginID := range f.logins {
+			if loginID == id {
+				fmt.Fprintf(w, `{"id":%s,"login":%q}`, id, login)
+				return
+			}
+		}
+		http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+
+	default:
+		http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,102 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/coderabbitai/terraform-provider-coderabbit/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &SeatByIDDataSource{}
+	_ datasource.DataSourceWithConfigure = &SeatByIDDataSource{}
+)
+
+// SeatByIDDataSource defines the seat-by-numeric-ID data source implementation
+type SeatByIDDataSource struct {
+	client *client.Client
+}
+
+// SeatByIDDataSourceModel describes the seat-by-numeric-ID data source data model
+type SeatByIDDataSourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	GitUserID types.String `tfsdk:"git_user_id"`
+	HasSeat   types.Bool   `tfsdk:"has_seat"`
+}
+
+// NewSeatByIDDataSource creates a new seat-by-numeric-ID data source
+func NewSeatByIDDataSource() datasource.DataSource {
+	return &SeatByIDDataSource{}
+}
+
+func (d *SeatByIDDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_seat_by_id"
+}
+
+func (d *SeatByIDDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the CodeRabbit seat status of a user by numeric Git user ID, without any GitHub API request. The seat list is cached and shared with the other seat resources and data sources.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The unique identifier for this data source.",
+				Computed:    true,
+			},
+			"git_user_id": schema.StringAttribute{
+				Description: "The numeric Git user ID to look up, e.g. 583231.",
+				Required:    true,
+				Validators: []validator.String{
+					numericID(),
+				},
+			},
+			"has_seat": schema.BoolAttribute{
+				Description: "Whether the user has a seat assigned.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *SeatByIDDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = c
+}
+
+func (d *SeatByIDDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SeatByIDDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	gitUserID := data.GitUserID.ValueString()
+	hasSeat, err := d.client.HasSeat(ctx, gitUserID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Seat Assignment",
+			fmt.Sprintf("Could not read seat assignment for user %s: %s", gitUserID, errorDetail(err)),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(gitUserID)
+	data.HasSeat = types.BoolValue(hasSeat)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
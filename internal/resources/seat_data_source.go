@@ -0,0 +1,126 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/coderabbitai/terraform-provider-coderabbit/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource                     = &SeatDataSource{}
+	_ datasource.DataSourceWithConfigure        = &SeatDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &SeatDataSource{}
+)
+
+// SeatDataSource defines the single-user seat data source implementation
+type SeatDataSource struct {
+	client *client.Client
+}
+
+// SeatDataSourceModel describes the single-user seat data source data model
+type SeatDataSourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	GitHubID  types.String `tfsdk:"github_id"`
+	GitUserID types.String `tfsdk:"git_user_id"`
+	HasSeat   types.Bool   `tfsdk:"has_seat"`
+}
+
+// NewSeatDataSource creates a new single-user seat data source
+func NewSeatDataSource() datasource.DataSource {
+	return &SeatDataSource{}
+}
+
+func (d *SeatDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_seat"
+}
+
+func (d *SeatDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the CodeRabbit seat status of a single user.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The unique identifier for this data source.",
+				Computed:    true,
+			},
+			"github_id": schema.StringAttribute{
+				Description: "The GitHub username to look up. Exactly one of github_id or git_user_id must be set.",
+				Optional:    true,
+			},
+			"git_user_id": schema.StringAttribute{
+				Description: "The numeric Git user ID to look up, or the ID resolved from github_id. Exactly one of github_id or git_user_id must be set.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"has_seat": schema.BoolAttribute{
+				Description: "Whether the user has a seat assigned.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *SeatDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		exactlyOneOf("github_id", "git_user_id"),
+	}
+}
+
+func (d *SeatDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = c
+}
+
+func (d *SeatDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SeatDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	gitUserID := data.GitUserID.ValueString()
+	if data.GitUserID.IsNull() {
+		githubID := data.GitHubID.ValueString()
+
+		var err error
+		gitUserID, err = d.client.GetGitUserID(ctx, githubID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Resolving GitHub User ID",
+				fmt.Sprintf("Could not resolve GitHub username '%s' to numeric ID: %s", githubID, err.Error()),
+			)
+			return
+		}
+	}
+
+	hasSeat, err := d.client.HasSeat(ctx, gitUserID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Seat Assignment",
+			fmt.Sprintf("Could not read seat assignment for user %s: %s", gitUserID, err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(gitUserID)
+	data.GitUserID = types.StringValue(gitUserID)
+	data.HasSeat = types.BoolValue(hasSeat)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,193 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/coderabbitai/terraform-provider-coderabbit/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &SeatReaperDataSource{}
+	_ datasource.DataSourceWithConfigure = &SeatReaperDataSource{}
+)
+
+// SeatReaperDataSource defines the seat reaper data source implementation
+type SeatReaperDataSource struct {
+	client *client.Client
+}
+
+// SeatReaperDataSourceModel describes the seat reaper data source data model
+type SeatReaperDataSourceModel struct {
+	ID           types.String        `tfsdk:"id"`
+	Org          types.String        `tfsdk:"org"`
+	InactiveDays types.Int64         `tfsdk:"inactive_days"`
+	GitUserIDs   []types.String      `tfsdk:"git_user_ids"`
+	Users        []InactiveSeatModel `tfsdk:"users"`
+}
+
+// InactiveSeatModel describes a single inactive seat in the seat reaper data source
+type InactiveSeatModel struct {
+	GitUserID      types.String `tfsdk:"git_user_id"`
+	GitHubLogin    types.String `tfsdk:"github_login"`
+	LastActivityAt types.String `tfsdk:"last_activity_at"`
+}
+
+// NewSeatReaperDataSource creates a new seat reaper data source
+func NewSeatReaperDataSource() datasource.DataSource {
+	return &SeatReaperDataSource{}
+}
+
+func (d *SeatReaperDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_seat_reaper"
+}
+
+func (d *SeatReaperDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Finds assigned seats whose users have had no GitHub activity in an organization's repositories for a number of days. It only reports candidates and never unassigns seats; remove the users from your seat configuration to reclaim them. Activity is read from the GitHub events API, which covers the last 90 days and only includes private activity the GitHub token can see. Requires GitHub authentication, since every seat costs a login lookup and at least one events request. Users whose activity can't be checked, e.g. because GitHub's limit of 300 events doesn't reach back far enough, are skipped with a warning rather than reported.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The unique identifier for this data source.",
+				Computed:    true,
+			},
+			"org": schema.StringAttribute{
+				Description: "The GitHub organization whose repositories count as activity.",
+				Required:    true,
+			},
+			"inactive_days": schema.Int64Attribute{
+				Description: "Number of days without activity after which a seat is reported, between 1 and 90.",
+				Required:    true,
+				Validators: []validator.Int64{
+					int64AtLeast(1),
+					int64AtMost(int64(client.GitHubEventsMaxAge / (24 * time.Hour))),
+				},
+			},
+			"git_user_ids": schema.ListAttribute{
+				Description: "Git user IDs of the inactive users with seats.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"users": schema.ListNestedAttribute{
+				Description: "Inactive users with seats.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"git_user_id": schema.StringAttribute{
+							Description: "The numeric Git user ID.",
+							Computed:    true,
+						},
+						"github_login": schema.StringAttribute{
+							Description: "The user's GitHub login.",
+							Computed:    true,
+						},
+						"last_activity_at": schema.StringAttribute{
+							Description: "RFC3339 time of the user's last event in the organization, or null if there was none within inactive_days.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *SeatReaperDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = c
+}
+
+func (d *SeatReaperDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SeatReaperDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Every seat costs a login lookup and at least one events page, which
+	// would exhaust the unauthenticated rate limit of 60 requests per hour
+	// in all but the smallest orgs, and private activity is only visible to
+	// an authenticated request
+	if !d.client.HasGitHubAuth() {
+		resp.Diagnostics.AddError(
+			"GitHub Authentication Required",
+			"Finding inactive seats reads every seat's GitHub login and activity and requires GitHub authentication. Set github_token (or GITHUB_TOKEN) or configure a GitHub App in the provider.",
+		)
+		return
+	}
+
+	seats, err := d.client.GetSeats(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Seats",
+			fmt.Sprintf("Could not read seat assignments: %s", errorDetail(err)),
+		)
+		return
+	}
+
+	org := data.Org.ValueString()
+	cutoff := time.Now().Add(-time.Duration(data.InactiveDays.ValueInt64()) * 24 * time.Hour)
+
+	data.GitUserIDs = []types.String{}
+	data.Users = []InactiveSeatModel{}
+	for _, user := range seats.Users {
+		if !user.SeatAssigned {
+			continue
+		}
+
+		// Users whose activity can't be checked are never reported, so a
+		// GitHub outage can't mark everyone as inactive
+		login, err := d.client.GetGitHubLogin(ctx, user.GitUserID)
+		if err != nil {
+			resp.Diagnostics.AddWarning(
+				"Error Resolving GitHub Login",
+				fmt.Sprintf("Could not resolve git_user_id %s to a GitHub login, so its activity was not checked: %s", user.GitUserID, err.Error()),
+			)
+			continue
+		}
+
+		lastActivity, err := d.client.GetGitHubLastActivity(ctx, login, org, cutoff)
+		if err != nil {
+			resp.Diagnostics.AddWarning(
+				"Error Reading GitHub Activity",
+				fmt.Sprintf("Could not read the GitHub activity of user %s, so it was not checked: %s", login, err.Error()),
+			)
+			continue
+		}
+		if lastActivity.After(cutoff) {
+			continue
+		}
+
+		lastActivityAt := types.StringNull()
+		if !lastActivity.IsZero() {
+			lastActivityAt = types.StringValue(lastActivity.UTC().Format(time.RFC3339))
+		}
+
+		data.GitUserIDs = append(data.GitUserIDs, types.StringValue(user.GitUserID))
+		data.Users = append(data.Users, InactiveSeatModel{
+			GitUserID:      types.StringValue(user.GitUserID),
+			GitHubLogin:    types.StringValue(login),
+			LastActivityAt: lastActivityAt,
+		})
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%s/%d", org, data.InactiveDays.ValueInt64()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,483 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/coderabbitai/terraform-provider-coderabbit/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &SeatsBulkResource{}
+	_ resource.ResourceWithConfigure   = &SeatsBulkResource{}
+	_ resource.ResourceWithImportState = &SeatsBulkResource{}
+)
+
+// SeatsBulkResource defines the bulk seats resource implementation
+type SeatsBulkResource struct {
+	client *client.Client
+}
+
+// SeatsBulkResourceModel describes the bulk seats resource data model
+type SeatsBulkResourceModel struct {
+	ID          types.String   `tfsdk:"id"`
+	GitHubIDs   []types.String `tfsdk:"github_ids"`
+	GitUserIDs  types.Map      `tfsdk:"git_user_ids"`
+	Concurrency types.Int64    `tfsdk:"concurrency"`
+}
+
+// NewSeatsBulkResource creates a new bulk seats resource
+func NewSeatsBulkResource() resource.Resource {
+	return &SeatsBulkResource{}
+}
+
+func (r *SeatsBulkResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_seats_bulk"
+}
+
+func (r *SeatsBulkResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages CodeRabbit seat assignments for a set of users at once. Seats are assigned and unassigned as users are added to or removed from the set. Failures for individual users are reported as warnings and retried on the next apply.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The unique identifier for this resource.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"github_ids": schema.SetAttribute{
+				Description: "The set of GitHub usernames that should have seats assigned.",
+				Required:    true,
+				ElementType: types.StringType,
+			},
+			"concurrency": schema.Int64Attribute{
+				Description: "Maximum number of parallel assign/unassign API calls. Defaults to 8.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(client.DefaultBulkConcurrency),
+				Validators: []validator.Int64{
+					int64AtLeast(1),
+				},
+			},
+			"git_user_ids": schema.MapAttribute{
+				Description: "Map of GitHub username to resolved numeric git_user_id for every user that currently has a seat managed by this resource.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (r *SeatsBulkResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = c
+}
+
+func (r *SeatsBulkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SeatsBulkResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	managed := r.reconcile(ctx, stringValues(data.GitHubIDs), map[string]string{}, int(data.Concurrency.ValueInt64()), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue("seats_bulk")
+	resp.Diagnostics.Append(setGitUserIDs(ctx, &data.GitUserIDs, managed)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SeatsBulkResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SeatsBulkResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	managed, diags := gitUserIDsMap(ctx, data.GitUserIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	assigned, err := assignedSeats(ctx, r.client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Seats",
+			fmt.Sprintf("Could not read seat assignments: %s", errorDetail(err)),
+		)
+		return
+	}
+
+	// Keep only users that still have a seat, so that users whose seat was
+	// removed outside Terraform (or never assigned) show up as drift
+	current := withSeats(managed, assigned)
+	var githubIDs []types.String
+	if data.GitHubIDs != nil {
+		// Keep an empty set empty rather than null, so that github_ids = []
+		// doesn't show a diff once no managed user is left
+		githubIDs = []types.String{}
+	}
+	for _, githubID := range sortedKeys(current) {
+		githubIDs = append(githubIDs, types.StringValue(githubID))
+	}
+
+	data.GitHubIDs = githubIDs
+	resp.Diagnostics.Append(setGitUserIDs(ctx, &data.GitUserIDs, current)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SeatsBulkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state SeatsBulkResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, diags := gitUserIDsMap(ctx, state.GitUserIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	managed := r.reconcile(ctx, stringValues(plan.GitHubIDs), current, int(plan.Concurrency.ValueInt64()), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+	resp.Diagnostics.Append(setGitUserIDs(ctx, &plan.GitUserIDs, managed)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *SeatsBulkResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SeatsBulkResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	managed, diags := gitUserIDsMap(ctx, data.GitUserIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	unassignManagedSeats(ctx, r.client, managed, int(data.Concurrency.ValueInt64()), &resp.Diagnostics)
+}
+
+// reconcile resolves the desired usernames and reconciles their seats with
+// the current users via reconcileSeats. Users already managed keep their
+// resolved git_user_id; usernames that can't be resolved are reported as a
+// warning and retried on the next apply.
+func (r *SeatsBulkResource) reconcile(ctx context.Context, desired []string, current map[string]string, concurrency int, diags *diag.Diagnostics) map[string]string {
+	desiredIDs := make(map[string]string, len(desired))
+	var toResolve []string
+	for _, githubID := range desired {
+		if gitUserID, ok := current[githubID]; ok {
+			desiredIDs[githubID] = gitUserID
+		} else {
+			toResolve = append(toResolve, githubID)
+		}
+	}
+
+	if len(toResolve) > 0 {
+		resolved, err := r.client.GetGitUserIDs(ctx, toResolve)
+		if err != nil {
+			diags.AddWarning(
+				"Error Resolving GitHub User IDs",
+				fmt.Sprintf("Some GitHub usernames could not be resolved and will be retried on the next apply: %s", err.Error()),
+			)
+		}
+		for _, githubID := range toResolve {
+			if gitUserID, ok := resolved[githubID]; ok {
+				desiredIDs[githubID] = gitUserID
+			}
+		}
+	}
+
+	return reconcileSeats(ctx, r.client, desiredIDs, current, concurrency, diags)
+}
+
+// reconcileSeats assigns seats to desired users without one and then
+// unassigns seats from current users whose git_user_id is no longer desired,
+// issuing up to concurrency API calls in parallel. Both maps are keyed by
+// username. It returns the resulting map of managed users, reporting per-user
+// failures as warnings; users whose seat could not be unassigned stay managed
+// so the removal is retried.
+//
+// Seats are assigned before any are unassigned, so that the seat count never
+// drops below the plan's, and a user that left the map under one username is
+// only unassigned when its git_user_id is not desired under another, e.g.
+// after a rename or a change of casing. Otherwise the seat would be removed
+// and then skipped as already assigned by the stale seats snapshot.
+func reconcileSeats(ctx context.Context, c *client.Client, desired, current map[string]string, concurrency int, diags *diag.Diagnostics) map[string]string {
+	assigned, err := assignedSeats(ctx, c)
+	if err != nil {
+		diags.AddError(
+			"Error Reading Seats",
+			fmt.Sprintf("Could not read seat assignments: %s", errorDetail(err)),
+		)
+		return nil
+	}
+
+	managed := make(map[string]string, len(desired))
+	desiredIDs := make(map[string]bool, len(desired))
+	var toAssign []string
+	for _, githubID := range sortedKeys(desired) {
+		gitUserID := desired[githubID]
+		desiredIDs[gitUserID] = true
+		if !assigned[gitUserID] {
+			toAssign = append(toAssign, githubID)
+			continue
+		}
+
+		if _, ok := current[githubID]; !ok {
+			tflog.Info(ctx, "Seat already assigned, skipping assign API call", map[string]interface{}{
+				"github_id":   githubID,
+				"git_user_id": gitUserID,
+			})
+		}
+		managed[githubID] = gitUserID
+	}
+
+	failed := c.AssignSeats(ctx, gitUserIDsOf(toAssign, desired), concurrency)
+	for _, githubID := range toAssign {
+		gitUserID := desired[githubID]
+		if err, ok := failed[gitUserID]; ok {
+			diags.AddWarning(
+				"Error Assigning Seat",
+				fmt.Sprintf("Could not assign seat to user %s (git_user_id: %s), will retry on the next apply: %s", githubID, gitUserID, errorDetail(err)),
+			)
+			continue
+		}
+		tflog.Info(ctx, "Seat assigned successfully", map[string]interface{}{
+			"github_id":   githubID,
+			"git_user_id": gitUserID,
+		})
+		managed[githubID] = gitUserID
+	}
+
+	var toRemove []string
+	for _, githubID := range sortedKeys(current) {
+		gitUserID := current[githubID]
+		switch {
+		case !assigned[gitUserID]:
+		case desiredIDs[gitUserID]:
+			if _, ok := desired[githubID]; !ok {
+				tflog.Info(ctx, "Seat still desired under another username, skipping unassign API call", map[string]interface{}{
+					"github_id":   githubID,
+					"git_user_id": gitUserID,
+				})
+			}
+		default:
+			toRemove = append(toRemove, githubID)
+		}
+	}
+
+	failed = c.UnassignSeats(ctx, gitUserIDsOf(toRemove, current), concurrency)
+	for _, githubID := range toRemove {
+		gitUserID := current[githubID]
+		if err, ok := failed[gitUserID]; ok {
+			diags.AddWarning(
+				"Error Unassigning Seat",
+				fmt.Sprintf("Could not unassign seat from user %s (git_user_id: %s), will retry on the next apply: %s", githubID, gitUserID, errorDetail(err)),
+			)
+			managed[githubID] = gitUserID
+			continue
+		}
+		tflog.Info(ctx, "Seat unassigned successfully", map[string]interface{}{
+			"github_id":   githubID,
+			"git_user_id": gitUserID,
+		})
+	}
+
+	return managed
+}
+
+// unassignManagedSeats unassigns the seats of every managed user that still
+// has one, reporting failures as errors
+func unassignManagedSeats(ctx context.Context, c *client.Client, managed map[string]string, concurrency int, diags *diag.Diagnostics) {
+	assigned, err := assignedSeats(ctx, c)
+	if err != nil {
+		diags.AddError(
+			"Error Reading Seats",
+			fmt.Sprintf("Could not read seat assignments: %s", errorDetail(err)),
+		)
+		return
+	}
+
+	toRemove := sortedKeys(withSeats(managed, assigned))
+	failed := c.UnassignSeats(ctx, gitUserIDsOf(toRemove, managed), concurrency)
+	for _, githubID := range toRemove {
+		gitUserID := managed[githubID]
+		if err, ok := failed[gitUserID]; ok {
+			diags.AddError(
+				"Error Unassigning Seat",
+				fmt.Sprintf("Could not unassign seat from user %s (git_user_id: %s): %s", githubID, gitUserID, errorDetail(err)),
+			)
+			continue
+		}
+		tflog.Info(ctx, "Seat unassigned successfully", map[string]interface{}{
+			"github_id":   githubID,
+			"git_user_id": gitUserID,
+		})
+	}
+}
+
+// importAllSeats is the import ID that adopts every currently assigned seat
+const importAllSeats = "*"
+
+// ImportState adopts every currently assigned seat when imported with ID "*".
+// Each git_user_id is reverse-resolved to its GitHub login; users whose login
+// cannot be resolved are skipped with a warning.
+func (r *SeatsBulkResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != importAllSeats {
+		resp.Diagnostics.AddError(
+			"Unsupported Import ID",
+			fmt.Sprintf("coderabbit_seats_bulk can only be imported with the ID %q, which adopts every assigned seat, got: %q", importAllSeats, req.ID),
+		)
+		return
+	}
+
+	seats, err := r.client.GetSeats(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Seats",
+			fmt.Sprintf("Could not read seat assignments: %s", errorDetail(err)),
+		)
+		return
+	}
+
+	managed := make(map[string]string)
+	githubIDs := []types.String{}
+	for _, user := range seats.Users {
+		if !user.SeatAssigned {
+			continue
+		}
+
+		login, err := r.client.GetGitHubLogin(ctx, user.GitUserID)
+		if err != nil {
+			resp.Diagnostics.AddWarning(
+				"Error Resolving GitHub Login",
+				fmt.Sprintf("Could not resolve git_user_id %s to a GitHub login, so its seat was not imported: %s", user.GitUserID, err.Error()),
+			)
+			continue
+		}
+
+		managed[login] = user.GitUserID
+		githubIDs = append(githubIDs, types.StringValue(login))
+	}
+
+	data := SeatsBulkResourceModel{
+		ID:          types.StringValue("seats_bulk"),
+		GitHubIDs:   githubIDs,
+		Concurrency: types.Int64Value(client.DefaultBulkConcurrency),
+	}
+	resp.Diagnostics.Append(setGitUserIDs(ctx, &data.GitUserIDs, managed)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// assignedSeats returns the set of git_user_ids that currently have a seat
+func assignedSeats(ctx context.Context, c *client.Client) (map[string]bool, error) {
+	seats, err := c.GetSeats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	assigned := make(map[string]bool, len(seats.Users))
+	for _, user := range seats.Users {
+		if user.SeatAssigned {
+			assigned[user.GitUserID] = true
+		}
+	}
+	return assigned, nil
+}
+
+// withSeats returns the users of managed whose git_user_id is assigned
+func withSeats(managed map[string]string, assigned map[string]bool) map[string]string {
+	result := make(map[string]string, len(managed))
+	for githubID, gitUserID := range managed {
+		if assigned[gitUserID] {
+			result[githubID] = gitUserID
+		}
+	}
+	return result
+}
+
+// gitUserIDsMap returns a git_user_ids attribute, mapping usernames to
+// git_user_ids, as a Go map
+func gitUserIDsMap(ctx context.Context, gitUserIDs types.Map) (map[string]string, diag.Diagnostics) {
+	managed := map[string]string{}
+	if gitUserIDs.IsNull() || gitUserIDs.IsUnknown() {
+		return managed, nil
+	}
+	diags := gitUserIDs.ElementsAs(ctx, &managed, false)
+	return managed, diags
+}
+
+// setGitUserIDs stores the managed users map in a git_user_ids attribute
+func setGitUserIDs(ctx context.Context, gitUserIDs *types.Map, managed map[string]string) diag.Diagnostics {
+	value, diags := types.MapValueFrom(ctx, types.StringType, managed)
+	*gitUserIDs = value
+	return diags
+}
+
+// stringValues converts a slice of framework strings to Go strings
+func stringValues(values []types.String) []string {
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		result = append(result, v.ValueString())
+	}
+	return result
+}
+
+// gitUserIDsOf returns the git_user_ids of the given usernames in ids
+func gitUserIDsOf(githubIDs []string, ids map[string]string) []string {
+	result := make([]string, 0, len(githubIDs))
+	for _, githubID := range githubIDs {
+		result = append(result, ids[githubID])
+	}
+	return result
+}
+
+// sortedKeys returns the keys of m in sorted order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
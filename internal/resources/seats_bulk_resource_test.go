@@ -0,0 +1,161 @@
+package resources
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSeatsBulkReconcile(t *testing.T) {
+	tests := []struct {
+		name          string
+		assigned      []string
+		current       map[string]string
+		desired       []string
+		wantManaged   map[string]string
+		wantSeats     []string
+		wantMutations []string
+	}{
+		{
+			name:          "add and remove users",
+			assigned:      []string{"1"},
+			current:       map[string]string{"alice": "1"},
+			desired:       []string{"bob"},
+			wantManaged:   map[string]string{"bob": "2"},
+			wantSeats:     []string{"2"},
+			wantMutations: []string{"assign 2", "unassign 1"},
+		},
+		{
+			name:          "username casing changed",
+			assigned:      []string{"1"},
+			current:       map[string]string{"Alice": "1"},
+			desired:       []string{"alice"},
+			wantManaged:   map[string]string{"alice": "1"},
+			wantSeats:     []string{"1"},
+			wantMutations: nil,
+		},
+		{
+			name:          "unchanged set",
+			assigned:      []string{"1", "2"},
+			current:       map[string]string{"alice": "1", "bob": "2"},
+			desired:       []string{"alice", "bob"},
+			wantManaged:   map[string]string{"alice": "1", "bob": "2"},
+			wantSeats:     []string{"1", "2"},
+			wantMutations: nil,
+		},
+		{
+			name:          "seat removed outside Terraform",
+			assigned:      nil,
+			current:       map[string]string{"alice": "1"},
+			desired:       []string{},
+			wantManaged:   map[string]string{},
+			wantSeats:     []string{},
+			wantMutations: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeAPI(map[string]string{"alice": "1", "bob": "2"}, tt.assigned...)
+			r := &SeatsBulkResource{client: newTestClient(t, api)}
+
+			var diags diag.Diagnostics
+			managed := r.reconcile(context.Background(), tt.desired, tt.current, 2, &diags)
+			if diags.HasError() || diags.WarningsCount() > 0 {
+				t.Fatalf("reconcile() diagnostics = %v", diags)
+			}
+
+			if !reflect.DeepEqual(managed, tt.wantManaged) {
+				t.Errorf("reconcile() = %v, want %v", managed, tt.wantManaged)
+			}
+			if got := api.seats(); !reflect.DeepEqual(got, tt.wantSeats) {
+				t.Errorf("seats after reconcile = %v, want %v", got, tt.wantSeats)
+			}
+			if got := api.mutations(); !reflect.DeepEqual(got, tt.wantMutations) {
+				t.Errorf("mutations = %v, want %v", got, tt.wantMutations)
+			}
+		})
+	}
+}
+
+func TestSeatsBulkReconcileUnresolvedUserWarns(t *testing.T) {
+	api := newFakeAPI(map[string]string{"alice": "1"})
+	r := &SeatsBulkResource{client: newTestClient(t, api)}
+
+	var diags diag.Diagnostics
+	managed := r.reconcile(context.Background(), []string{"alice", "ghost"}, map[string]string{}, 2, &diags)
+	if diags.HasError() {
+		t.Fatalf("reconcile() errors = %v", diags)
+	}
+	if diags.WarningsCount() != 1 {
+		t.Errorf("reconcile() warnings = %v, want one for the unresolved user", diags)
+	}
+	if want := map[string]string{"alice": "1"}; !reflect.DeepEqual(managed, want) {
+		t.Errorf("reconcile() = %v, want %v", managed, want)
+	}
+}
+
+// readSeatsBulk runs Read on r for a state managing the given users with
+// the given github_ids, and returns the github_ids read back
+func readSeatsBulk(t *testing.T, r *SeatsBulkResource, githubIDs tftypes.Value, managed map[string]string) types.Set {
+	t.Helper()
+
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	s := schemaResp.Schema
+
+	ids := make(map[string]tftypes.Value, len(managed))
+	for githubID, gitUserID := range managed {
+		ids[githubID] = tftypes.NewValue(tftypes.String, gitUserID)
+	}
+	objType := s.Type().TerraformType(ctx).(tftypes.Object)
+	state := tfsdk.State{Schema: s, Raw: tftypes.NewValue(objType, map[string]tftypes.Value{
+		"id":           tftypes.NewValue(tftypes.String, "seats_bulk"),
+		"github_ids":   githubIDs,
+		"git_user_ids": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, ids),
+		"concurrency":  tftypes.NewValue(tftypes.Number, 2),
+	})}
+
+	resp := resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read() = %v", resp.Diagnostics)
+	}
+
+	var got types.Set
+	if diags := resp.State.GetAttribute(ctx, path.Root("github_ids"), &got); diags.HasError() {
+		t.Fatalf("State.GetAttribute(github_ids) = %v", diags)
+	}
+	return got
+}
+
+func TestSeatsBulkReadKeepsEmptySet(t *testing.T) {
+	setType := tftypes.Set{ElementType: tftypes.String}
+	r := &SeatsBulkResource{client: newTestClient(t, newFakeAPI(nil))}
+
+	// github_ids = [] stays an empty set
+	got := readSeatsBulk(t, r, tftypes.NewValue(setType, []tftypes.Value{}), nil)
+	if got.IsNull() || len(got.Elements()) != 0 {
+		t.Errorf("github_ids after Read of an empty set = %s, want []", got)
+	}
+
+	// An empty set is also left when the last managed seat is gone
+	got = readSeatsBulk(t, r, tftypes.NewValue(setType, []tftypes.Value{tftypes.NewValue(tftypes.String, "alice")}), map[string]string{"alice": "1"})
+	if got.IsNull() || len(got.Elements()) != 0 {
+		t.Errorf("github_ids after Read without seats = %s, want []", got)
+	}
+
+	// A null prior value stays null
+	got = readSeatsBulk(t, r, tftypes.NewValue(setType, nil), nil)
+	if !got.IsNull() {
+		t.Errorf("github_ids after Read of a null set = %s, want null", got)
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/coderabbitai/terraform-provider-coderabbit/internal/client"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -22,9 +24,30 @@ type SeatsDataSource struct {
 
 // SeatsDataSourceModel describes the data source data model
 type SeatsDataSourceModel struct {
-	ID                types.String   `tfsdk:"id"`
-	UsersWithSeats    []types.String `tfsdk:"users_with_seats"`
-	UsersWithoutSeats []types.String `tfsdk:"users_without_seats"`
+	ID                types.String    `tfsdk:"id"`
+	UsersWithSeats    []types.String  `tfsdk:"users_with_seats"`
+	UsersWithoutSeats []types.String  `tfsdk:"users_without_seats"`
+	TotalSeats        types.Int64     `tfsdk:"total_seats"`
+	AssignedCount     types.Int64     `tfsdk:"assigned_count"`
+	UnassignedCount   types.Int64     `tfsdk:"unassigned_count"`
+	SeatLimit         types.Int64     `tfsdk:"seat_limit"`
+	ResolveUsernames  types.Bool      `tfsdk:"resolve_usernames"`
+	Filter            types.String    `tfsdk:"filter"`
+	Users             []SeatUserModel `tfsdk:"users"`
+}
+
+// Values of the seats data source filter attribute
+const (
+	seatsFilterAll        = "all"
+	seatsFilterAssigned   = "assigned"
+	seatsFilterUnassigned = "unassigned"
+)
+
+// SeatUserModel describes a single user in the seats data source
+type SeatUserModel struct {
+	GitUserID    types.String `tfsdk:"git_user_id"`
+	GitHubLogin  types.String `tfsdk:"github_login"`
+	SeatAssigned types.Bool   `tfsdk:"seat_assigned"`
 }
 
 // NewSeatsDataSource creates a new seats data source
@@ -54,6 +77,53 @@ func (d *SeatsDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 				Computed:    true,
 				ElementType: types.StringType,
 			},
+			"total_seats": schema.Int64Attribute{
+				Description: "Total number of users known to CodeRabbit, with or without seats.",
+				Computed:    true,
+			},
+			"assigned_count": schema.Int64Attribute{
+				Description: "Number of users with seats assigned.",
+				Computed:    true,
+			},
+			"unassigned_count": schema.Int64Attribute{
+				Description: "Number of users without seats assigned.",
+				Computed:    true,
+			},
+			"seat_limit": schema.Int64Attribute{
+				Description: "Maximum number of seats available, if reported by the CodeRabbit API. Null otherwise.",
+				Computed:    true,
+			},
+			"resolve_usernames": schema.BoolAttribute{
+				Description: "When true, reverse-resolves each git_user_id to its GitHub login in users. Requires GitHub authentication (github_token or a GitHub App).",
+				Optional:    true,
+			},
+			"filter": schema.StringAttribute{
+				Description: "Which users to list: assigned, unassigned or all. Defaults to all. The filtered-out list is left empty and users only contains matching users; the counts always cover all users.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringOneOf(seatsFilterAll, seatsFilterAssigned, seatsFilterUnassigned),
+				},
+			},
+			"users": schema.ListNestedAttribute{
+				Description: "Users known to CodeRabbit with their seat status, limited by filter.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"git_user_id": schema.StringAttribute{
+							Description: "The numeric Git user ID.",
+							Computed:    true,
+						},
+						"github_login": schema.StringAttribute{
+							Description: "The user's GitHub login. Only populated when resolve_usernames is true.",
+							Computed:    true,
+						},
+						"seat_assigned": schema.BoolAttribute{
+							Description: "Whether the user has a seat assigned.",
+							Computed:    true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -83,7 +153,17 @@ func (d *SeatsDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	seats, err := d.client.GetSeats()
+	resolveUsernames := data.ResolveUsernames.ValueBool()
+	if resolveUsernames && !d.client.HasGitHubAuth() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("resolve_usernames"),
+			"GitHub Authentication Required",
+			"Resolving usernames requires GitHub authentication. Set github_token (or GITHUB_TOKEN) or configure a GitHub App in the provider.",
+		)
+		return
+	}
+
+	seats, err := d.client.GetSeats(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Seats",
@@ -94,20 +174,64 @@ func (d *SeatsDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 
 	data.ID = types.StringValue("seats")
 
+	filter := seatsFilterAll
+	if !data.Filter.IsNull() {
+		filter = data.Filter.ValueString()
+	}
+
 	// Separate users by seat assignment status
-	var usersWithSeats []types.String
-	var usersWithoutSeats []types.String
+	usersWithSeats := []types.String{}
+	usersWithoutSeats := []types.String{}
+	users := make([]SeatUserModel, 0, len(seats.Users))
+	var assignedCount, unassignedCount int64
 
 	for _, user := range seats.Users {
+		if user.SeatAssigned {
+			assignedCount++
+		} else {
+			unassignedCount++
+		}
+
+		if (filter == seatsFilterAssigned && !user.SeatAssigned) || (filter == seatsFilterUnassigned && user.SeatAssigned) {
+			continue
+		}
+
 		if user.SeatAssigned {
 			usersWithSeats = append(usersWithSeats, types.StringValue(user.GitUserID))
 		} else {
 			usersWithoutSeats = append(usersWithoutSeats, types.StringValue(user.GitUserID))
 		}
+
+		githubLogin := types.StringNull()
+		if resolveUsernames {
+			login, err := d.client.GetGitHubLogin(ctx, user.GitUserID)
+			if err != nil {
+				resp.Diagnostics.AddWarning(
+					"Error Resolving GitHub Login",
+					fmt.Sprintf("Could not resolve git_user_id %s to a GitHub login: %s", user.GitUserID, err.Error()),
+				)
+			} else {
+				githubLogin = types.StringValue(login)
+			}
+		}
+
+		users = append(users, SeatUserModel{
+			GitUserID:    types.StringValue(user.GitUserID),
+			GitHubLogin:  githubLogin,
+			SeatAssigned: types.BoolValue(user.SeatAssigned),
+		})
 	}
 
 	data.UsersWithSeats = usersWithSeats
 	data.UsersWithoutSeats = usersWithoutSeats
+	data.Users = users
+	data.TotalSeats = types.Int64Value(int64(len(seats.Users)))
+	data.AssignedCount = types.Int64Value(assignedCount)
+	data.UnassignedCount = types.Int64Value(unassignedCount)
+	data.SeatLimit = types.Int64Null()
+	if seats.SeatLimit != nil {
+		data.SeatLimit = types.Int64Value(*seats.SeatLimit)
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -0,0 +1,235 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/coderabbitai/terraform-provider-coderabbit/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &SeatsImportDataSource{}
+	_ datasource.DataSourceWithConfigure = &SeatsImportDataSource{}
+)
+
+// SeatsImportDataSource generates import blocks for every assigned seat, so
+// that an existing organization can be brought under Terraform in one step
+type SeatsImportDataSource struct {
+	client *client.Client
+}
+
+// SeatsImportDataSourceModel describes the seats import data source data model
+type SeatsImportDataSourceModel struct {
+	ID                   types.String            `tfsdk:"id"`
+	ResourceName         types.String            `tfsdk:"resource_name"`
+	ImportIDs            map[string]types.String `tfsdk:"import_ids"`
+	UnresolvedGitUserIDs []types.String          `tfsdk:"unresolved_git_user_ids"`
+	ImportBlocks         types.String            `tfsdk:"import_blocks"`
+}
+
+// NewSeatsImportDataSource creates a new seats import data source
+func NewSeatsImportDataSource() datasource.DataSource {
+	return &SeatsImportDataSource{}
+}
+
+func (d *SeatsImportDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_seats_import"
+}
+
+func (d *SeatsImportDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Generates coderabbit_seats import blocks for every assigned seat. Seat IDs are reverse-resolved to GitHub logins, so imported seats are managed by github_id. Logins made only of digits are imported as `login:<login>` so that they aren't taken for git_user_ids. Seats whose IDs GitHub can't resolve, e.g. deleted accounts, are imported by git_user_id instead. Requires GitHub authentication.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The unique identifier for this data source.",
+				Computed:    true,
+			},
+			"resource_name": schema.StringAttribute{
+				Description: "Name of a coderabbit_seats resource using for_each to import into, e.g. `this` for `coderabbit_seats.this[\"octocat\"]`. When unset, each seat gets its own resource named after the login, e.g. `coderabbit_seats.octocat`, as expected by `terraform plan -generate-config-out`.",
+				Optional:    true,
+			},
+			"import_ids": schema.MapAttribute{
+				Description: "Import IDs keyed by GitHub login, or by resource ID (`github:<git_user_id>`) for seats that could not be resolved. Can be used as the for_each of an import block.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"unresolved_git_user_ids": schema.ListAttribute{
+				Description: "git_user_ids of assigned seats that GitHub could not resolve to a login, sorted.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"import_blocks": schema.StringAttribute{
+				Description: "Terraform import blocks for every assigned seat, sorted by key, ready to be written to a .tf file.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *SeatsImportDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = c
+}
+
+func (d *SeatsImportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SeatsImportDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Reverse resolution makes one GitHub request per seat, which would
+	// exhaust the unauthenticated rate limit in all but the smallest orgs
+	if !d.client.HasGitHubAuth() {
+		resp.Diagnostics.AddError(
+			"GitHub Authentication Required",
+			"Generating seat imports resolves every seat to a GitHub login and requires GitHub authentication. Set github_token (or GITHUB_TOKEN) or configure a GitHub App in the provider.",
+		)
+		return
+	}
+
+	seats, err := d.client.GetSeats(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Seats",
+			fmt.Sprintf("Could not read seat assignments: %s", errorDetail(err)),
+		)
+		return
+	}
+
+	var gitUserIDs []string
+	for _, user := range seats.Users {
+		if user.SeatAssigned {
+			gitUserIDs = append(gitUserIDs, user.GitUserID)
+		}
+	}
+
+	logins, err := d.client.GetGitHubLogins(ctx, gitUserIDs)
+	if err != nil && ctx.Err() != nil {
+		resp.Diagnostics.AddError(
+			"Error Resolving GitHub Logins",
+			fmt.Sprintf("Could not resolve seats to GitHub logins: %s", err.Error()),
+		)
+		return
+	}
+
+	importIDs := make(map[string]types.String, len(gitUserIDs))
+	unresolved := []types.String{}
+	comments := make(map[string]string)
+	for _, gitUserID := range gitUserIDs {
+		if login, ok := logins[gitUserID]; ok {
+			importIDs[login] = types.StringValue(loginImportID(login))
+			continue
+		}
+		// Keyed by resource ID rather than the bare git_user_id, which could
+		// equal the login of another seat made only of digits
+		key := seatID(client.ProviderTypeGitHub, gitUserID)
+		if _, ok := importIDs[key]; ok {
+			continue
+		}
+		importIDs[key] = types.StringValue(gitUserID)
+		unresolved = append(unresolved, types.StringValue(gitUserID))
+		comments[key] = fmt.Sprintf("git_user_id %s could not be resolved to a GitHub login and is imported by ID", gitUserID)
+	}
+	sort.Slice(unresolved, func(i, j int) bool {
+		return unresolved[i].ValueString() < unresolved[j].ValueString()
+	})
+
+	if len(unresolved) > 0 {
+		ids := make([]string, 0, len(unresolved))
+		for _, id := range unresolved {
+			ids = append(ids, id.ValueString())
+		}
+		resp.Diagnostics.AddWarning(
+			"Some Seats Not Resolved to GitHub Logins",
+			fmt.Sprintf("%d seat(s) could not be resolved to a GitHub login, e.g. because the account was deleted, and are imported by git_user_id: %s", len(ids), strings.Join(ids, ", ")),
+		)
+	}
+
+	data.ID = types.StringValue("seats_import")
+	data.ImportIDs = importIDs
+	data.UnresolvedGitUserIDs = unresolved
+	data.ImportBlocks = types.StringValue(seatImportBlocks(importIDs, comments, data.ResourceName.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// loginImportID returns the coderabbit_seats import ID of a GitHub login.
+// Logins made only of digits get the login: prefix, as a bare number would be
+// imported as a git_user_id.
+func loginImportID(login string) string {
+	if isNumeric(login) {
+		return importLoginPrefix + login
+	}
+	return login
+}
+
+// seatImportBlocks renders an import block per entry of importIDs, sorted by
+// key. Keys become instance keys of resourceName when it is set, and
+// otherwise the names of separate resources.
+func seatImportBlocks(importIDs map[string]types.String, comments map[string]string, resourceName string) string {
+	keys := make([]string, 0, len(importIDs))
+	for key := range importIDs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	names := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		to := fmt.Sprintf("coderabbit_seats.%s[%q]", resourceName, key)
+		if resourceName == "" {
+			name := importResourceName(key)
+			if names[name] {
+				name += "_" + strings.TrimPrefix(importIDs[key].ValueString(), importLoginPrefix)
+			}
+			names[name] = true
+			to = "coderabbit_seats." + name
+		}
+
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		if comment, ok := comments[key]; ok {
+			fmt.Fprintf(&b, "# %s\n", comment)
+		}
+		fmt.Fprintf(&b, "import {\n  to = %s\n  id = %q\n}\n", to, importIDs[key].ValueString())
+	}
+	return b.String()
+}
+
+// importResourceName turns a GitHub login or resource ID into a Terraform
+// resource name: lowercase, with characters other than letters, digits,
+// underscores and hyphens replaced, and prefixed when it doesn't start with a
+// letter or underscore
+func importResourceName(key string) string {
+	name := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' || r == '-' {
+			return r
+		}
+		return '_'
+	}, strings.ToLower(key))
+
+	if name == "" || !((name[0] >= 'a' && name[0] <= 'z') || name[0] == '_') {
+		name = "user_" + name
+	}
+	return name
+}
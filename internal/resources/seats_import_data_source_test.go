@@ -0,0 +1,128 @@
+package resources
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// readSeatsImport runs the coderabbit_seats_import data source against api
+func readSeatsImport(t *testing.T, api *fakeAPI) SeatsImportDataSourceModel {
+	t.Helper()
+
+	ctx := context.Background()
+	c := newTestClient(t, api)
+	c.GitHubToken = "test-token"
+	d := &SeatsImportDataSource{client: c}
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	objType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := make(map[string]tftypes.Value, len(objType.AttributeTypes))
+	for name, attrType := range objType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+
+	req := datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objType, values)}}
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objType, nil)}}
+	d.Read(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read() = %v", resp.Diagnostics)
+	}
+
+	var data SeatsImportDataSourceModel
+	if diags := resp.State.Get(ctx, &data); diags.HasError() {
+		t.Fatalf("State.Get() = %v", diags)
+	}
+	return data
+}
+
+func TestSeatsImportIDs(t *testing.T) {
+	api := newFakeAPI(map[string]string{"octocat": "1", "1234": "2"}, "1", "2", "3")
+	data := readSeatsImport(t, api)
+
+	want := map[string]string{"octocat": "octocat", "1234": "login:1234", "github:3": "3"}
+	if len(data.ImportIDs) != len(want) {
+		t.Fatalf("import_ids = %v, want %v", data.ImportIDs, want)
+	}
+	for key, id := range want {
+		if got := data.ImportIDs[key].ValueString(); got != id {
+			t.Errorf("import_ids[%q] = %q, want %q", key, got, id)
+		}
+	}
+}
+
+func TestSeatsImportIDsNumericLoginAndUnresolvedSeat(t *testing.T) {
+	// The login 1234 and the unresolved git_user_id 1234 belong to different
+	// seats and must both be imported
+	for _, order := range [][]string{{"2", "1234"}, {"1234", "2"}} {
+		api := newFakeAPI(map[string]string{"1234": "2"}, order...)
+		data := readSeatsImport(t, api)
+
+		want := map[string]string{"1234": "login:1234", "github:1234": "1234"}
+		if len(data.ImportIDs) != len(want) {
+			t.Fatalf("seats %v: import_ids = %v, want %v", order, data.ImportIDs, want)
+		}
+		for key, id := range want {
+			if got := data.ImportIDs[key].ValueString(); got != id {
+				t.Errorf("seats %v: import_ids[%q] = %q, want %q", order, key, got, id)
+			}
+		}
+		if got := data.UnresolvedGitUserIDs; len(got) != 1 || got[0].ValueString() != "1234" {
+			t.Errorf("seats %v: unresolved_git_user_ids = %v, want [1234]", order, got)
+		}
+	}
+}
+
+func TestSeatsImportBlocksImportEverySeat(t *testing.T) {
+	// Each generated import block must import the seat it was generated for,
+	// including logins made only of digits
+	api := newFakeAPI(map[string]string{"octocat": "1", "1234": "2"}, "1", "2", "3")
+	blocks := readSeatsImport(t, api).ImportBlocks.ValueString()
+
+	matches := regexp.MustCompile(`(?m)^  id = "([^"]+)"$`).FindAllStringSubmatch(blocks, -1)
+	if len(matches) != 3 {
+		t.Fatalf("import blocks = %q, want 3 blocks", blocks)
+	}
+
+	imported := make(map[string]bool)
+	for _, m := range matches {
+		data, ok := importSeat(t, api, m[1])
+		if !ok {
+			t.Errorf("ImportState(%q) failed", m[1])
+			continue
+		}
+		imported[data.GitUserID.ValueString()] = true
+	}
+	for _, id := range []string{"1", "2", "3"} {
+		if !imported[id] {
+			t.Errorf("no import block imports git_user_id %s:\n%s", id, blocks)
+		}
+	}
+}
+
+func TestSeatImportBlocksResourceNames(t *testing.T) {
+	// A digit-only login and an unresolved git_user_id map to the same
+	// resource name and must still render valid, distinct names
+	importIDs := map[string]types.String{
+		"1234":      types.StringValue("login:1234"),
+		"user_1234": types.StringValue("user_1234"),
+	}
+	blocks := seatImportBlocks(importIDs, nil, "")
+
+	names := regexp.MustCompile(`(?m)^  to = coderabbit_seats\.(\S+)$`).FindAllStringSubmatch(blocks, -1)
+	if len(names) != 2 || names[0][1] == names[1][1] {
+		t.Fatalf("import blocks = %q, want two distinct resource names", blocks)
+	}
+	valid := regexp.MustCompile(`^[a-z_][a-z0-9_-]*$`)
+	for _, name := range names {
+		if !valid.MatchString(name[1]) {
+			t.Errorf("resource name %q is not a valid Terraform name", name[1])
+		}
+	}
+}
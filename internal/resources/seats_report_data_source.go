@@ -0,0 +1,174 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/coderabbitai/terraform-provider-coderabbit/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &SeatsReportDataSource{}
+	_ datasource.DataSourceWithConfigure = &SeatsReportDataSource{}
+)
+
+// SeatsReportDataSource defines the seats report data source implementation
+type SeatsReportDataSource struct {
+	client *client.Client
+}
+
+// SeatsReportDataSourceModel describes the seats report data source data model
+type SeatsReportDataSourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	ResolveUsernames types.Bool   `tfsdk:"resolve_usernames"`
+	JSON             types.String `tfsdk:"json"`
+}
+
+// seatsReport is the document encoded in the json attribute
+type seatsReport struct {
+	Total      int               `json:"total"`
+	Assigned   int               `json:"assigned"`
+	Unassigned int               `json:"unassigned"`
+	SeatLimit  *int64            `json:"seat_limit,omitempty"`
+	Users      []seatsReportUser `json:"users"`
+}
+
+// seatsReportUser is a single user of a seatsReport
+type seatsReportUser struct {
+	GitUserID    string `json:"git_user_id"`
+	GitHubLogin  string `json:"github_login,omitempty"`
+	SeatAssigned bool   `json:"seat_assigned"`
+	AssignedAt   string `json:"assigned_at,omitempty"`
+	Role         string `json:"role,omitempty"`
+}
+
+// NewSeatsReportDataSource creates a new seats report data source
+func NewSeatsReportDataSource() datasource.DataSource {
+	return &SeatsReportDataSource{}
+}
+
+func (d *SeatsReportDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_seats_report"
+}
+
+func (d *SeatsReportDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Summarizes CodeRabbit seat assignments as a single JSON document, e.g. for dashboards.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The unique identifier for this data source.",
+				Computed:    true,
+			},
+			"resolve_usernames": schema.BoolAttribute{
+				Description: "When true, adds each user's GitHub login to the report. Requires GitHub authentication (github_token or a GitHub App).",
+				Optional:    true,
+			},
+			"json": schema.StringAttribute{
+				Description: "JSON object with total, assigned and unassigned counts, seat_limit when reported by the API, and a users list of git_user_id, github_login, seat_assigned, assigned_at and role, sorted by git_user_id.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *SeatsReportDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = c
+}
+
+func (d *SeatsReportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SeatsReportDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resolveUsernames := data.ResolveUsernames.ValueBool()
+	if resolveUsernames && !d.client.HasGitHubAuth() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("resolve_usernames"),
+			"GitHub Authentication Required",
+			"Resolving usernames requires GitHub authentication. Set github_token (or GITHUB_TOKEN) or configure a GitHub App in the provider.",
+		)
+		return
+	}
+
+	seats, err := d.client.GetSeats(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Seats",
+			fmt.Sprintf("Could not read seat assignments: %s", errorDetail(err)),
+		)
+		return
+	}
+
+	report := seatsReport{
+		Total:     len(seats.Users),
+		SeatLimit: seats.SeatLimit,
+		Users:     make([]seatsReportUser, 0, len(seats.Users)),
+	}
+	for _, user := range seats.Users {
+		if user.SeatAssigned {
+			report.Assigned++
+		} else {
+			report.Unassigned++
+		}
+
+		reportUser := seatsReportUser{
+			GitUserID:    user.GitUserID,
+			SeatAssigned: user.SeatAssigned,
+			AssignedAt:   user.AssignedAt,
+			Role:         user.Role,
+		}
+		if resolveUsernames {
+			login, err := d.client.GetGitHubLogin(ctx, user.GitUserID)
+			if err != nil {
+				resp.Diagnostics.AddWarning(
+					"Error Resolving GitHub Login",
+					fmt.Sprintf("Could not resolve git_user_id %s to a GitHub login: %s", user.GitUserID, err.Error()),
+				)
+			} else {
+				reportUser.GitHubLogin = login
+			}
+		}
+		report.Users = append(report.Users, reportUser)
+	}
+
+	// Sort so that the document only changes when seats do
+	sort.Slice(report.Users, func(i, j int) bool {
+		return report.Users[i].GitUserID < report.Users[j].GitUserID
+	})
+
+	encoded, err := json.Marshal(report)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Encoding Seats Report",
+			fmt.Sprintf("Could not encode the seats report as JSON: %s", err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue("seats_report")
+	data.JSON = types.StringValue(string(encoded))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -3,21 +3,29 @@ package resources
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/coderabbitai/terraform-provider-coderabbit/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var (
-	_ resource.Resource              = &SeatsResource{}
-	_ resource.ResourceWithConfigure = &SeatsResource{}
-	_ resource.ResourceWithImportState = &SeatsResource{}
+	_ resource.Resource                     = &SeatsResource{}
+	_ resource.ResourceWithConfigure        = &SeatsResource{}
+	_ resource.ResourceWithImportState      = &SeatsResource{}
+	_ resource.ResourceWithConfigValidators = &SeatsResource{}
+	_ resource.ResourceWithModifyPlan       = &SeatsResource{}
 )
 
 // SeatsResource defines the resource implementation
@@ -27,9 +35,16 @@ type SeatsResource struct {
 
 // SeatsResourceModel describes the resource data model
 type SeatsResourceModel struct {
-	ID        types.String `tfsdk:"id"`
-	GitHubID  types.String `tfsdk:"github_id"`
-	GitUserID types.String `tfsdk:"git_user_id"`
+	ID           types.String `tfsdk:"id"`
+	GitHubID     types.String `tfsdk:"github_id"`
+	GitUserID    types.String `tfsdk:"git_user_id"`
+	Email        types.String `tfsdk:"email"`
+	ProviderType types.String `tfsdk:"provider_type"`
+	GitHubLogin  types.String `tfsdk:"github_login"`
+	AssignedAt   types.String `tfsdk:"assigned_at"`
+	Role         types.String `tfsdk:"role"`
+
+	PreventUnassign types.Bool `tfsdk:"prevent_unassign"`
 }
 
 // NewSeatsResource creates a new seats resource
@@ -43,33 +58,101 @@ func (r *SeatsResource) Metadata(ctx context.Context, req resource.MetadataReque
 
 func (r *SeatsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Manages a CodeRabbit seat assignment for a user.",
+		Version:     seatsSchemaVersion,
+		Description: "Manages a CodeRabbit seat assignment for a user. Import by GitHub username or numeric git_user_id, e.g. `octocat`, or by `login:<username>` for usernames made only of digits; this fails if the user has no seat. Append `!force`, e.g. `octocat!force`, to import a user without a seat so that the next apply assigns it.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Description: "The unique identifier for this resource.",
+				Description: "The unique identifier for this resource, in the form <provider_type>:<git_user_id>, e.g. github:12345678.",
 				Computed:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
 			"github_id": schema.StringAttribute{
-				Description: "The GitHub username (e.g., 'octocat'). The provider will automatically resolve this to the numeric git_user_id.",
-				Required:    true,
+				Description: "The GitHub username (e.g., 'octocat'). The provider will automatically resolve this to the numeric git_user_id. Usernames are case-insensitive, so changing only the casing does not replace the seat. Set github_id, git_user_id or both, or email instead.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						requiresReplaceIfUsernameChanged,
+						"Replaces the seat unless only the username casing changed.",
+						"Replaces the seat unless only the username casing changed.",
+					),
+				},
+				Validators: []validator.String{
+					githubUsername(),
+				},
+			},
+			"github_login": schema.StringAttribute{
+				Description: "The canonical GitHub login of the user, as returned by the GitHub API. It can differ from github_id in casing, and follows renames on refresh. Looking it up for seats not resolved by github_id or email requires GitHub authentication. Null for other provider types or when it couldn't be looked up.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					useStateForUnknownUnlessUserChanged{},
+				},
+			},
+			"provider_type": schema.StringAttribute{
+				Description: "The Git platform the user belongs to: github, gitlab or bitbucket. Defaults to github. For gitlab, github_id is the GitLab username; for bitbucket, it is the Bitbucket account ID or UUID.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(client.ProviderTypeGitHub),
+				Validators: []validator.String{
+					stringOneOf(client.ProviderTypeGitHub, client.ProviderTypeGitLab, client.ProviderTypeBitbucket),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
-			"git_user_id": schema.StringAttribute{
-				Description: "The resolved numeric GitHub user ID. This is computed automatically from github_id.",
+			"assigned_at": schema.StringAttribute{
+				Description: "RFC3339 timestamp of when the seat was assigned. Taken from the CodeRabbit API when available, otherwise the time Terraform assigned or first recorded the seat.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"role": schema.StringAttribute{
+				Description: "The seat role: reviewer or admin. Changing it updates the seat in place. If not set, the API default is used and the role reported by the API is recorded.",
+				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
+				Validators: []validator.String{
+					stringOneOf(client.SeatRoleReviewer, client.SeatRoleAdmin),
+				},
+			},
+			"prevent_unassign": schema.BoolAttribute{
+				Description: "When true, destroying this resource only removes it from Terraform state and leaves the seat assigned. Useful for imported seats that were assigned outside Terraform. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"email": schema.StringAttribute{
+				Description: "The user's email address. The provider resolves it to a GitHub user via the GitHub search API, which requires a github_token and only matches public profile emails. Cannot be combined with github_id or git_user_id.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"git_user_id": schema.StringAttribute{
+				Description: "The numeric Git user ID. Computed automatically from github_id or email, or set directly to skip GitHub resolution (e.g. for service accounts or non-GitHub users). When set together with github_id, as in generated configuration, both must name the same user.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					useStateForUnknownUnlessUserChanged{},
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 		},
 	}
 }
 
+func (r *SeatsResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		// github_id and git_user_id may be set together, as in configuration
+		// generated for imported seats
+		exactlyOneOfGroups([]string{"github_id", "git_user_id"}, []string{"email"}),
+	}
+}
+
 func (r *SeatsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -87,6 +170,55 @@ func (r *SeatsResource) Configure(ctx context.Context, req resource.ConfigureReq
 	r.client = c
 }
 
+// ModifyPlan checks that a github_id added to a seat imported by git_user_id
+// names the seat's user. The attribute plan modifiers keep such a seat in
+// place, since only the Git platform can tell, and a github_id naming a
+// different user replaces the seat here instead.
+func (r *SeatsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check on create, on destroy or before the provider is configured
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan, state SeatsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() || !addsGitHubID(plan.GitHubID, state.GitHubID, state.GitUserID) {
+		return
+	}
+
+	providerType := plan.ProviderType.ValueString()
+	githubID := plan.GitHubID.ValueString()
+	gitUserID := state.GitUserID.ValueString()
+	resolved, err := r.client.ResolveUserID(ctx, providerType, githubID)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("github_id"),
+			"Error Resolving Git User ID",
+			fmt.Sprintf("Could not resolve %s username '%s' to numeric ID to compare it with the seat's git_user_id %s: %s", providerType, githubID, gitUserID, err.Error()),
+		)
+		return
+	}
+	if resolved == gitUserID {
+		return
+	}
+
+	tflog.Info(ctx, "github_id names a different user than the imported seat, planning replacement", map[string]interface{}{
+		"github_id":   githubID,
+		"git_user_id": gitUserID,
+		"resolved":    resolved,
+	})
+	// A configured git_user_id is kept, so that Create reports the conflict
+	var configGitUserID types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("git_user_id"), &configGitUserID)...)
+	if configGitUserID.IsNull() {
+		plan.GitUserID = types.StringUnknown()
+	}
+	plan.GitHubLogin = types.StringUnknown()
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("github_id"))
+}
+
 func (r *SeatsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data SeatsResourceModel
 
@@ -96,51 +228,133 @@ func (r *SeatsResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	githubID := data.GitHubID.ValueString()
+	gitUserID := data.GitUserID.ValueString()
 
-	// Resolve GitHub username to numeric user ID
-	gitUserID, err := r.client.GetGitUserID(githubID)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Resolving GitHub User ID",
-			fmt.Sprintf("Could not resolve GitHub username '%s' to numeric ID: %s", githubID, err.Error()),
+	// userLabel identifies the user in logs and diagnostics
+	userLabel := githubID
+
+	providerType := data.ProviderType.ValueString()
+
+	switch {
+	case !data.Email.IsNull() && providerType != client.ProviderTypeGitHub:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("email"),
+			"Unsupported Email Resolution",
+			fmt.Sprintf("Resolving users by email is only supported for provider_type github, got %s.", providerType),
 		)
 		return
+	case !data.Email.IsNull():
+		// Resolve email address to numeric user ID
+		email := data.Email.ValueString()
+		warnMissingGitHubScopes(ctx, r.client, &resp.Diagnostics, "resolving users by email needs user:email", "user:email")
+		var err error
+		gitUserID, err = r.client.GetGitUserIDByEmail(ctx, email)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("email"),
+				"Error Resolving Email Address",
+				fmt.Sprintf("Could not resolve email '%s' to a GitHub user: %s", email, err.Error()),
+			)
+			return
+		}
+		userLabel = email
+	case data.GitUserID.IsNull() || data.GitUserID.IsUnknown():
+		// Resolve username to numeric user ID on the configured platform
+		var err error
+		gitUserID, err = r.client.ResolveUserID(ctx, providerType, githubID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Resolving Git User ID",
+				fmt.Sprintf("Could not resolve %s username '%s' to numeric ID: %s", providerType, githubID, err.Error()),
+			)
+			return
+		}
+	case !data.GitHubID.IsNull():
+		// github_id and git_user_id given together, e.g. by generated
+		// configuration, must still name the same user
+		resolved, err := r.client.ResolveUserID(ctx, providerType, githubID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Resolving Git User ID",
+				fmt.Sprintf("Could not resolve %s username '%s' to numeric ID: %s", providerType, githubID, err.Error()),
+			)
+			return
+		}
+		if resolved != gitUserID {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("git_user_id"),
+				"Conflicting User Attributes",
+				fmt.Sprintf("github_id '%s' resolves to git_user_id %s, but git_user_id is set to %s. Remove one of them or make them name the same user.", githubID, resolved, gitUserID),
+			)
+			return
+		}
+	default:
+		// git_user_id given directly, skip GitHub resolution
+		userLabel = gitUserID
 	}
 
 	// Check if seat is already assigned (idempotency)
-	hasSeat, err := r.client.HasSeat(gitUserID)
+	seatUser, err := r.client.GetSeatUser(ctx, gitUserID)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Checking Seat Assignment",
-			fmt.Sprintf("Could not check seat assignment for user %s: %s", githubID, err.Error()),
+			fmt.Sprintf("Could not check seat assignment for user %s: %s", userLabel, errorDetail(err)),
 		)
 		return
 	}
 
-	if hasSeat {
+	assignedAt := time.Now().UTC().Format(time.RFC3339)
+	role := ""
+	if !data.Role.IsNull() && !data.Role.IsUnknown() {
+		role = data.Role.ValueString()
+	}
+
+	if seatUser != nil && (role == "" || seatUser.Role == role) {
+		if seatUser.AssignedAt != "" {
+			assignedAt = seatUser.AssignedAt
+		}
+		role = seatUser.Role
+
 		// Seat already assigned, just record the state
 		tflog.Info(ctx, "Seat already assigned, skipping assign API call", map[string]interface{}{
-			"github_id":   githubID,
+			"user":        userLabel,
 			"git_user_id": gitUserID,
 		})
 	} else {
-		// Assign seat
-		err = r.client.AssignSeat(gitUserID)
+		if seatUser != nil && seatUser.AssignedAt != "" {
+			assignedAt = seatUser.AssignedAt
+		}
+
+		// Assign seat, or change the role of the existing one
+		err = r.client.AssignSeatWithRole(ctx, gitUserID, role)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Assigning Seat",
-				fmt.Sprintf("Could not assign seat to user %s (git_user_id: %s): %s", githubID, gitUserID, err.Error()),
+				fmt.Sprintf("Could not assign seat to user %s (git_user_id: %s): %s", userLabel, gitUserID, errorDetail(err)),
 			)
 			return
 		}
 		tflog.Info(ctx, "Seat assigned successfully", map[string]interface{}{
-			"github_id":   githubID,
+			"user":        userLabel,
 			"git_user_id": gitUserID,
 		})
+
+		// The seats list is eventually consistent; wait for the new seat so
+		// the next Read doesn't drop the resource from state
+		if err := r.client.WaitForSeat(ctx, gitUserID, r.client.SeatWaitTimeout); err != nil {
+			resp.Diagnostics.AddWarning(
+				"Seat Assignment Not Yet Visible",
+				fmt.Sprintf("The seat was assigned to user %s (git_user_id: %s) but could not be confirmed in the seats list: %s. "+
+					"If the next plan shows the seat as missing, refresh again once CodeRabbit has caught up, or raise seat_wait_timeout.", userLabel, gitUserID, err.Error()),
+			)
+		}
 	}
 
-	data.ID = types.StringValue(gitUserID)
+	data.ID = types.StringValue(seatID(providerType, gitUserID))
 	data.GitUserID = types.StringValue(gitUserID)
+	data.AssignedAt = types.StringValue(assignedAt)
+	data.Role = optionalString(role)
+	data.GitHubLogin = r.githubLogin(ctx, data)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -155,16 +369,33 @@ func (r *SeatsResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	gitUserID := data.GitUserID.ValueString()
 
-	hasSeat, err := r.client.HasSeat(gitUserID)
+	// State written before provider_type existed refers to GitHub users
+	if data.ProviderType.IsNull() {
+		data.ProviderType = types.StringValue(client.ProviderTypeGitHub)
+	}
+	if data.PreventUnassign.IsNull() {
+		data.PreventUnassign = types.BoolValue(false)
+	}
+
+	// A forced import has no seat yet; keep it so the next apply assigns one
+	if isStagedImport(data) {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	// GetSeatUser already reports an organization without a seats list as no
+	// seat, so any error here, including a 404, is a failed read rather than
+	// a removed seat
+	seatUser, err := r.client.GetSeatUser(ctx, gitUserID)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Seat Assignment",
-			fmt.Sprintf("Could not read seat assignment for user %s: %s", gitUserID, err.Error()),
+			fmt.Sprintf("Could not read seat assignment for user %s: %s", gitUserID, errorDetail(err)),
 		)
 		return
 	}
 
-	if !hasSeat {
+	if seatUser == nil {
 		// Resource no longer exists, remove from state
 		tflog.Info(ctx, "Seat not found, removing from state", map[string]interface{}{
 			"git_user_id": gitUserID,
@@ -173,20 +404,172 @@ func (r *SeatsResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	refreshSeatState(ctx, &data, seatUser)
+	data.GitHubLogin = r.githubLogin(ctx, data)
+	r.checkGitHubRename(ctx, data, &resp.Diagnostics)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// checkGitHubRename warns when github_id no longer resolves to the seat's
+// git_user_id, e.g. because the user renamed their GitHub account. The seat
+// is keyed by the numeric ID, so it is kept and only the configuration needs
+// updating. The check needs GitHub authentication to avoid exhausting the
+// unauthenticated rate limit, and lookup failures are only logged.
+func (r *SeatsResource) checkGitHubRename(ctx context.Context, data SeatsResourceModel, diags *diag.Diagnostics) {
+	if data.ProviderType.ValueString() != client.ProviderTypeGitHub || data.GitHubID.IsNull() || !r.client.HasGitHubAuth() {
+		return
+	}
+
+	githubID := data.GitHubID.ValueString()
+	gitUserID := data.GitUserID.ValueString()
+	if resolved, err := r.client.GetGitUserID(ctx, githubID); err == nil && resolved == gitUserID {
+		return
+	}
+
+	login, err := r.client.GetGitHubLogin(ctx, gitUserID)
+	if err != nil {
+		tflog.Warn(ctx, "Could not check GitHub username for renames", map[string]interface{}{
+			"github_id":   githubID,
+			"git_user_id": gitUserID,
+			"error":       err.Error(),
+		})
+		return
+	}
+	if strings.EqualFold(login, githubID) {
+		return
+	}
+
+	diags.AddWarning(
+		"GitHub User Renamed",
+		fmt.Sprintf("github_id %q no longer refers to git_user_id %s, whose GitHub login is now %q. "+
+			"The seat is kept, but update github_id to %q in your configuration so that a later change doesn't resolve the old name.", githubID, gitUserID, login, login),
+	)
+}
+
+// githubLogin returns the canonical GitHub login of the seat's user. Logins
+// already resolved during this run are reused; any other lookup needs GitHub
+// authentication, so refreshing many seats can't exhaust the unauthenticated
+// rate limit. When the login can't be determined, the known value in data is
+// kept and lookup failures are only logged.
+func (r *SeatsResource) githubLogin(ctx context.Context, data SeatsResourceModel) types.String {
+	if data.ProviderType.ValueString() != client.ProviderTypeGitHub {
+		return types.StringNull()
+	}
+
+	prior := data.GitHubLogin
+	if prior.IsUnknown() {
+		prior = types.StringNull()
+	}
+
+	gitUserID := data.GitUserID.ValueString()
+	if login, ok := r.client.CachedGitHubLogin(gitUserID); ok {
+		return types.StringValue(login)
+	}
+	if !r.client.HasGitHubAuth() {
+		return prior
+	}
+
+	login, err := r.client.GetGitHubLogin(ctx, gitUserID)
+	if err != nil {
+		tflog.Warn(ctx, "Could not look up GitHub login", map[string]interface{}{
+			"git_user_id": gitUserID,
+			"error":       err.Error(),
+		})
+		return prior
+	}
+	return types.StringValue(login)
+}
+
+// refreshSeatState copies the seat fields reported by the API into data so that
+// changes made outside Terraform show up as drift on the next plan. Fields the
+// API leaves empty keep their state value.
+func refreshSeatState(ctx context.Context, data *SeatsResourceModel, seatUser *client.SeatUser) {
+	fields := []struct {
+		name  string
+		state *types.String
+		api   string
+	}{
+		{"assigned_at", &data.AssignedAt, seatUser.AssignedAt},
+		{"role", &data.Role, seatUser.Role},
+	}
+
+	for _, field := range fields {
+		if field.api == "" || field.state.ValueString() == field.api {
+			continue
+		}
+
+		if !field.state.IsNull() {
+			tflog.Info(ctx, "Seat changed outside Terraform", map[string]interface{}{
+				"attribute": field.name,
+				"state":     field.state.ValueString(),
+				"api":       field.api,
+			})
+		}
+		*field.state = types.StringValue(field.api)
+	}
+}
+
 func (r *SeatsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// Since github_id has RequiresReplace, Update should never be called
-	// But we implement it for safety
-	var data SeatsResourceModel
+	var plan, state SeatsResourceModel
 
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	// Changing the user requires replacement, so the seat and its resolved
+	// identifiers always carry over from state
+	plan.ID = state.ID
+	plan.GitUserID = state.GitUserID
+	plan.AssignedAt = state.AssignedAt
+	plan.GitHubLogin = state.GitHubLogin
+	if plan.Role.IsUnknown() {
+		plan.Role = state.Role
+	}
+
+	if !seatUpdateRequired(plan, state) {
+		tflog.Info(ctx, "No seat changes to apply, updating state only", map[string]interface{}{
+			"git_user_id": state.GitUserID.ValueString(),
+		})
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+		return
+	}
+
+	gitUserID := state.GitUserID.ValueString()
+	if err := r.client.AssignSeatWithRole(ctx, gitUserID, plan.Role.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Seat",
+			fmt.Sprintf("Could not update seat for user %s: %s", gitUserID, errorDetail(err)),
+		)
+		return
+	}
+
+	tflog.Info(ctx, "Seat updated successfully", map[string]interface{}{
+		"git_user_id": gitUserID,
+		"role":        plan.Role.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// seatUpdateRequired reports whether plan changes a seat setting that must be
+// sent to the API. Identity attributes force replacement and github_id is
+// only updated in place for casing changes, so neither needs an API call.
+func seatUpdateRequired(plan, state SeatsResourceModel) bool {
+	if plan.Role.IsNull() || plan.Role.IsUnknown() {
+		return false
+	}
+	return !plan.Role.Equal(state.Role)
+}
+
+// optionalString returns a null string for an empty value
+func optionalString(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
 }
 
 func (r *SeatsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -199,16 +582,41 @@ func (r *SeatsResource) Delete(ctx context.Context, req resource.DeleteRequest,
 
 	gitUserID := data.GitUserID.ValueString()
 
+	if data.PreventUnassign.ValueBool() {
+		tflog.Warn(ctx, "prevent_unassign is set, leaving seat assigned", map[string]interface{}{
+			"git_user_id": gitUserID,
+		})
+		resp.Diagnostics.AddWarning(
+			"Seat Left Assigned",
+			fmt.Sprintf("The seat of user %s was removed from Terraform state but left assigned in CodeRabbit because prevent_unassign is true.", gitUserID),
+		)
+		return
+	}
+
 	// Check if seat is still assigned before unassigning (idempotency)
-	hasSeat, err := r.client.HasSeat(gitUserID)
+	hasSeat, err := r.client.HasSeat(ctx, gitUserID)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Checking Seat Assignment",
-			fmt.Sprintf("Could not check seat assignment for user %s: %s", gitUserID, err.Error()),
+			fmt.Sprintf("Could not check seat assignment for user %s: %s", gitUserID, errorDetail(err)),
 		)
 		return
 	}
 
+	if !hasSeat {
+		// A cached seats list may predate an assignment made elsewhere, so
+		// confirm against a fresh list before skipping the unassign
+		r.client.InvalidateSeatsCache()
+		hasSeat, err = r.client.HasSeat(ctx, gitUserID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Checking Seat Assignment",
+				fmt.Sprintf("Could not check seat assignment for user %s: %s", gitUserID, errorDetail(err)),
+			)
+			return
+		}
+	}
+
 	if !hasSeat {
 		// Seat already unassigned, nothing to do
 		tflog.Info(ctx, "Seat already unassigned, skipping unassign API call", map[string]interface{}{
@@ -217,11 +625,11 @@ func (r *SeatsResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	err = r.client.UnassignSeat(gitUserID)
+	err = r.client.UnassignSeat(ctx, gitUserID)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Unassigning Seat",
-			fmt.Sprintf("Could not unassign seat from user %s: %s", gitUserID, err.Error()),
+			fmt.Sprintf("Could not unassign seat from user %s: %s", gitUserID, errorDetail(err)),
 		)
 		return
 	}
@@ -231,22 +639,51 @@ func (r *SeatsResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	})
 }
 
-// ImportState allows importing existing seat assignments
-func (r *SeatsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Import by github_id
-	githubID := req.ID
+const (
+	// importForceSuffix marks an import ID that should be staged even when the
+	// user has no seat, so that the next apply assigns it
+	importForceSuffix = "!force"
 
-	gitUserID, err := r.client.GetGitUserID(githubID)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Importing Seat",
-			fmt.Sprintf("Could not resolve GitHub username '%s': %s", githubID, err.Error()),
-		)
-		return
+	// importLoginPrefix marks an import ID as a GitHub username, so that
+	// usernames made only of digits aren't taken for git_user_ids
+	importLoginPrefix = "login:"
+)
+
+// ImportState allows importing existing seat assignments by resource ID
+// (<provider_type>:<git_user_id>), GitHub username, login:<username> or
+// numeric git_user_id.
+// With the !force suffix, a user without a seat is imported as a staged state
+// holding only id and provider_type, which the next plan replaces with an assignment.
+func (r *SeatsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importID, force := strings.CutSuffix(req.ID, importForceSuffix)
+	githubID := importID
+	gitUserID := importID
+	providerType := client.ProviderTypeGitHub
+
+	// Resource IDs and numeric IDs are taken as git_user_id directly, anything
+	// else as a GitHub username
+	login, byLogin := strings.CutPrefix(importID, importLoginPrefix)
+	numericID := !byLogin && isNumeric(importID)
+	if byLogin {
+		githubID = login
+	} else if pt, id, ok := parseSeatID(importID); ok {
+		providerType, gitUserID, githubID = pt, id, id
+		numericID = true
+	}
+	if !numericID {
+		var err error
+		gitUserID, err = r.client.GetGitUserID(ctx, githubID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Importing Seat",
+				fmt.Sprintf("Could not resolve GitHub username '%s': %s", githubID, err.Error()),
+			)
+			return
+		}
 	}
 
 	// Check if seat exists
-	hasSeat, err := r.client.HasSeat(gitUserID)
+	hasSeat, err := r.client.HasSeat(ctx, gitUserID)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Checking Seat",
@@ -255,15 +692,144 @@ func (r *SeatsResource) ImportState(ctx context.Context, req resource.ImportStat
 		return
 	}
 
-	if !hasSeat {
+	if !hasSeat && !force {
 		resp.Diagnostics.AddError(
 			"Seat Not Found",
-			fmt.Sprintf("User '%s' (git_user_id: %s) does not have a seat assigned", githubID, gitUserID),
+			fmt.Sprintf("User '%s' (git_user_id: %s) does not have a seat assigned. To import it anyway and assign the seat on the next apply, use the import ID '%s%s'.", githubID, gitUserID, importID, importForceSuffix),
 		)
 		return
 	}
 
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), gitUserID)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("github_id"), githubID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), seatID(providerType, gitUserID))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("provider_type"), providerType)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("prevent_unassign"), false)...)
+
+	if !hasSeat {
+		// Leave github_id, git_user_id and assigned_at null so that the
+		// configured user differs from state and the plan assigns the seat
+		tflog.Info(ctx, "Seat not found, staging import for assignment on next apply", map[string]interface{}{
+			"git_user_id": gitUserID,
+		})
+		return
+	}
+
+	if !numericID {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("github_id"), githubID)...)
+	}
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("git_user_id"), gitUserID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("assigned_at"), time.Now().UTC().Format(time.RFC3339))...)
+}
+
+// seatID builds the resource ID of a seat, e.g. github:12345678. The
+// provider type prefix keeps IDs unique across Git platforms.
+func seatID(providerType, gitUserID string) string {
+	return providerType + ":" + gitUserID
+}
+
+// parseSeatID splits a resource ID built by seatID. It reports false for
+// anything else, including legacy bare git_user_id IDs.
+func parseSeatID(id string) (providerType, gitUserID string, ok bool) {
+	providerType, gitUserID, found := strings.Cut(id, ":")
+	if !found || gitUserID == "" {
+		return "", "", false
+	}
+
+	switch providerType {
+	case client.ProviderTypeGitHub, client.ProviderTypeGitLab, client.ProviderTypeBitbucket:
+		return providerType, gitUserID, true
+	}
+	return "", "", false
+}
+
+// isStagedImport reports whether data was written by a forced import of a
+// user without a seat. Created and regular imported seats always record
+// git_user_id and assigned_at.
+func isStagedImport(data SeatsResourceModel) bool {
+	return data.GitUserID.IsNull() && data.AssignedAt.IsNull()
+}
+
+// requiresReplaceIfUsernameChanged requires replacement when github_id names a
+// different user. Usernames are case-insensitive, so a case-only change is
+// applied in place and keeps the resolved git_user_id. A github_id added to a
+// seat imported by git_user_id is checked against the seat by ModifyPlan.
+func requiresReplaceIfUsernameChanged(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	var stateGitUserID types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("git_user_id"), &stateGitUserID)...)
+	resp.RequiresReplace = !sameUsername(req.PlanValue, req.StateValue) && !addsGitHubID(req.PlanValue, req.StateValue, stateGitUserID)
+}
+
+// addsGitHubID reports whether a planned github_id is set on a seat whose
+// state has a git_user_id but no github_id, as left by importing the seat by
+// git_user_id or resource ID
+func addsGitHubID(planGitHubID, stateGitHubID, stateGitUserID types.String) bool {
+	return stateGitHubID.IsNull() && isKnown(planGitHubID) && isKnown(stateGitUserID)
+}
+
+// isKnown reports whether a value is known and not null
+func isKnown(value types.String) bool {
+	return !value.IsNull() && !value.IsUnknown()
+}
+
+// sameUsername reports whether a and b name the same user, ignoring case and
+// surrounding whitespace. Two null values are the same; null and set are not.
+func sameUsername(a, b types.String) bool {
+	if a.IsNull() || b.IsNull() {
+		return a.IsNull() == b.IsNull()
+	}
+	return strings.EqualFold(strings.TrimSpace(a.ValueString()), strings.TrimSpace(b.ValueString()))
+}
+
+var _ planmodifier.String = useStateForUnknownUnlessUserChanged{}
+
+// useStateForUnknownUnlessUserChanged keeps the prior value of a computed
+// user attribute, git_user_id or github_login, while github_id, email and a
+// configured git_user_id still name the same user. When any of them changes,
+// the seat is replaced and the value is left unknown so that Create resolves
+// the new user rather than carrying over the old one from state.
+type useStateForUnknownUnlessUserChanged struct{}
+
+func (m useStateForUnknownUnlessUserChanged) Description(ctx context.Context) string {
+	return "Uses the prior value unless github_id, email or git_user_id names a different user."
+}
+
+func (m useStateForUnknownUnlessUserChanged) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m useStateForUnknownUnlessUserChanged) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.StateValue.IsNull() || !req.PlanValue.IsUnknown() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var planGitHubID, stateGitHubID, planEmail, stateEmail, configGitUserID, stateGitUserID types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("github_id"), &planGitHubID)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("github_id"), &stateGitHubID)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("email"), &planEmail)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("email"), &stateEmail)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("git_user_id"), &configGitUserID)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("git_user_id"), &stateGitUserID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if planGitHubID.IsUnknown() || planEmail.IsUnknown() || configGitUserID.IsUnknown() ||
+		!(sameUsername(planGitHubID, stateGitHubID) || addsGitHubID(planGitHubID, stateGitHubID, stateGitUserID)) || !planEmail.Equal(stateEmail) ||
+		(!configGitUserID.IsNull() && !configGitUserID.Equal(stateGitUserID)) {
+		return
+	}
+
+	resp.PlanValue = req.StateValue
+}
+
+// isNumeric reports whether s is a non-empty string of ASCII digits
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
 }
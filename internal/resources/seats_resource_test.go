@@ -0,0 +1,504 @@
+package resources
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// seatsSchema returns the coderabbit_seats schema
+func seatsSchema() schema.Schema {
+	var resp resource.SchemaResponse
+	NewSeatsResource().Schema(context.Background(), resource.SchemaRequest{}, &resp)
+	return resp.Schema
+}
+
+// seatsValue builds a coderabbit_seats object from the given attribute
+// values; every other attribute is null
+func seatsValue(t *testing.T, attrs map[string]tftypes.Value) tftypes.Value {
+	t.Helper()
+
+	objType := seatsSchema().Type().TerraformType(context.Background()).(tftypes.Object)
+	values := make(map[string]tftypes.Value, len(objType.AttributeTypes))
+	for name, attrType := range objType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	for name, value := range attrs {
+		if _, ok := values[name]; !ok {
+			t.Fatalf("unknown coderabbit_seats attribute %q", name)
+		}
+		values[name] = value
+	}
+	return tftypes.NewValue(objType, values)
+}
+
+// str returns a known tftypes string value
+func str(s string) tftypes.Value {
+	return tftypes.NewValue(tftypes.String, s)
+}
+
+func TestSeatsConfigValidators(t *testing.T) {
+	tests := []struct {
+		name    string
+		attrs   map[string]tftypes.Value
+		wantErr bool
+	}{
+		{"github_id", map[string]tftypes.Value{"github_id": str("octocat")}, false},
+		{"git_user_id", map[string]tftypes.Value{"git_user_id": str("1")}, false},
+		{"email", map[string]tftypes.Value{"email": str("octocat@example.com")}, false},
+		{"generated configuration", map[string]tftypes.Value{"github_id": str("octocat"), "git_user_id": str("1")}, false},
+		{"none", nil, true},
+		{"email and github_id", map[string]tftypes.Value{"email": str("octocat@example.com"), "github_id": str("octocat")}, true},
+		{"email and git_user_id", map[string]tftypes.Value{"email": str("octocat@example.com"), "git_user_id": str("1")}, true},
+		{"unknown", map[string]tftypes.Value{"github_id": tftypes.NewValue(tftypes.String, tftypes.UnknownValue)}, false},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := resource.ValidateConfigRequest{
+				Config: tfsdk.Config{Schema: seatsSchema(), Raw: seatsValue(t, tt.attrs)},
+			}
+			var resp resource.ValidateConfigResponse
+			for _, v := range NewSeatsResource().(*SeatsResource).ConfigValidators(ctx) {
+				v.ValidateResource(ctx, req, &resp)
+			}
+			if got := resp.Diagnostics.HasError(); got != tt.wantErr {
+				t.Errorf("ValidateResource() error = %v, want %v: %v", got, tt.wantErr, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+// importSeat runs ImportState for id against api and returns the imported state
+func importSeat(t *testing.T, api *fakeAPI, id string) (SeatsResourceModel, bool) {
+	t.Helper()
+
+	ctx := context.Background()
+	r := &SeatsResource{client: newTestClient(t, api)}
+	s := seatsSchema()
+	resp := resource.ImportStateResponse{
+		State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)},
+	}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: id}, &resp)
+	if resp.Diagnostics.HasError() {
+		return SeatsResourceModel{}, false
+	}
+
+	var data SeatsResourceModel
+	if diags := resp.State.Get(ctx, &data); diags.HasError() {
+		t.Fatalf("State.Get() = %v", diags)
+	}
+	return data, true
+}
+
+func TestSeatsImportState(t *testing.T) {
+	logins := map[string]string{"octocat": "1", "1234": "2"}
+
+	tests := []struct {
+		name          string
+		id            string
+		wantID        string
+		wantGitHubID  string
+		wantGitUserID string
+		wantErr       bool
+	}{
+		{name: "username", id: "octocat", wantID: "github:1", wantGitHubID: "octocat", wantGitUserID: "1"},
+		{name: "git_user_id", id: "1", wantID: "github:1", wantGitUserID: "1"},
+		{name: "resource ID", id: "github:1", wantID: "github:1", wantGitUserID: "1"},
+		{name: "digit-only login", id: "login:1234", wantID: "github:2", wantGitHubID: "1234", wantGitUserID: "2"},
+		{name: "prefixed login", id: "login:octocat", wantID: "github:1", wantGitHubID: "octocat", wantGitUserID: "1"},
+		{name: "unknown login", id: "login:ghost", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, ok := importSeat(t, newFakeAPI(logins, "1", "2"), tt.id)
+			if ok == tt.wantErr {
+				t.Fatalf("ImportState(%q) succeeded = %v, want %v", tt.id, ok, !tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := data.ID.ValueString(); got != tt.wantID {
+				t.Errorf("id = %q, want %q", got, tt.wantID)
+			}
+			if got := data.GitHubID.ValueString(); got != tt.wantGitHubID {
+				t.Errorf("github_id = %q, want %q", got, tt.wantGitHubID)
+			}
+			if got := data.GitUserID.ValueString(); got != tt.wantGitUserID {
+				t.Errorf("git_user_id = %q, want %q", got, tt.wantGitUserID)
+			}
+		})
+	}
+}
+
+// createSeat runs Create with the given configured attributes against api
+func createSeat(t *testing.T, api *fakeAPI, attrs map[string]tftypes.Value) (SeatsResourceModel, bool) {
+	t.Helper()
+
+	ctx := context.Background()
+	unknown := tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	plan := map[string]tftypes.Value{
+		"id":               unknown,
+		"provider_type":    str("github"),
+		"github_login":     unknown,
+		"assigned_at":      unknown,
+		"role":             unknown,
+		"git_user_id":      unknown,
+		"prevent_unassign": tftypes.NewValue(tftypes.Bool, false),
+	}
+	for name, value := range attrs {
+		plan[name] = value
+	}
+
+	r := &SeatsResource{client: newTestClient(t, api)}
+	s := seatsSchema()
+	req := resource.CreateRequest{Plan: tfsdk.Plan{Schema: s, Raw: seatsValue(t, plan)}}
+	resp := resource.CreateResponse{
+		State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)},
+	}
+	r.Create(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		for _, d := range resp.Diagnostics.Errors() {
+			t.Logf("Create() error: %s: %s", d.Summary(), d.Detail())
+		}
+		return SeatsResourceModel{}, false
+	}
+
+	var data SeatsResourceModel
+	if diags := resp.State.Get(ctx, &data); diags.HasError() {
+		t.Fatalf("State.Get() = %v", diags)
+	}
+	return data, true
+}
+
+func TestSeatsCreateWithGeneratedConfig(t *testing.T) {
+	logins := map[string]string{"octocat": "1"}
+
+	api := newFakeAPI(logins)
+	data, ok := createSeat(t, api, map[string]tftypes.Value{"github_id": str("octocat"), "git_user_id": str("1")})
+	if !ok {
+		t.Fatal("Create() with matching github_id and git_user_id failed")
+	}
+	if got := data.ID.ValueString(); got != "github:1" {
+		t.Errorf("id = %q, want github:1", got)
+	}
+	if got := api.seats(); !reflect.DeepEqual(got, []string{"1"}) {
+		t.Errorf("seats = %v, want [1]", got)
+	}
+
+	api = newFakeAPI(logins)
+	if _, ok := createSeat(t, api, map[string]tftypes.Value{"github_id": str("octocat"), "git_user_id": str("2")}); ok {
+		t.Error("Create() with conflicting github_id and git_user_id succeeded")
+	}
+	if got := api.mutations(); len(got) != 0 {
+		t.Errorf("mutations = %v, want none", got)
+	}
+}
+
+func TestSeatsImportStateForce(t *testing.T) {
+	logins := map[string]string{"octocat": "1"}
+
+	// Without !force, importing a user without a seat fails
+	if _, ok := importSeat(t, newFakeAPI(logins), "octocat"); ok {
+		t.Error("ImportState(octocat) without a seat succeeded, want an error")
+	}
+
+	for _, id := range []string{"octocat!force", "1!force", "login:octocat!force"} {
+		t.Run(id, func(t *testing.T) {
+			api := newFakeAPI(logins)
+			data, ok := importSeat(t, api, id)
+			if !ok {
+				t.Fatalf("ImportState(%q) failed", id)
+			}
+			if got := data.ID.ValueString(); got != "github:1" {
+				t.Errorf("id = %q, want github:1", got)
+			}
+			if !isStagedImport(data) {
+				t.Errorf("ImportState(%q) = %+v, want a staged import for the next apply to assign", id, data)
+			}
+			if !data.GitHubID.IsNull() {
+				t.Errorf("github_id = %s, want null so the configured user differs from state", data.GitHubID)
+			}
+			if got := api.mutations(); len(got) != 0 {
+				t.Errorf("mutations = %v, want none during import", got)
+			}
+		})
+	}
+
+	// With a seat, !force imports it like the strict form
+	data, ok := importSeat(t, newFakeAPI(logins, "1"), "octocat!force")
+	if !ok {
+		t.Fatal("ImportState(octocat!force) with a seat failed")
+	}
+	if isStagedImport(data) || data.GitHubID.ValueString() != "octocat" || data.GitUserID.ValueString() != "1" {
+		t.Errorf("ImportState(octocat!force) = %+v, want the assigned seat", data)
+	}
+}
+
+// planSeatChange plans an update of state to the configured attributes: it
+// runs the plan modifiers of github_id, git_user_id and github_login, like
+// the framework, and then ModifyPlan on r. It reports whether replacement is
+// planned, the planned git_user_id and the diagnostics of ModifyPlan.
+func planSeatChange(t *testing.T, r *SeatsResource, state tfsdk.State, configAttrs map[string]tftypes.Value) (bool, types.String, diag.Diagnostics) {
+	t.Helper()
+
+	ctx := context.Background()
+	s := seatsSchema()
+	unknown := tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	planAttrs := map[string]tftypes.Value{
+		"id":               unknown,
+		"provider_type":    str("github"),
+		"git_user_id":      unknown,
+		"github_login":     unknown,
+		"assigned_at":      unknown,
+		"role":             unknown,
+		"prevent_unassign": tftypes.NewValue(tftypes.Bool, false),
+	}
+	for name, value := range configAttrs {
+		planAttrs[name] = value
+	}
+	config := tfsdk.Config{Schema: s, Raw: seatsValue(t, configAttrs)}
+	plan := tfsdk.Plan{Schema: s, Raw: seatsValue(t, planAttrs)}
+
+	replace := false
+	for _, name := range []string{"github_id", "git_user_id", "github_login"} {
+		var stateValue, configValue, planValue types.String
+		state.GetAttribute(ctx, path.Root(name), &stateValue)
+		config.GetAttribute(ctx, path.Root(name), &configValue)
+		plan.GetAttribute(ctx, path.Root(name), &planValue)
+		req := planmodifier.StringRequest{
+			Path:        path.Root(name),
+			State:       state,
+			Config:      config,
+			Plan:        plan,
+			StateValue:  stateValue,
+			ConfigValue: configValue,
+		}
+		resp := planmodifier.StringResponse{PlanValue: planValue}
+		// Like the framework, hand each modifier the value planned by the previous one
+		for _, m := range s.Attributes[name].(schema.StringAttribute).PlanModifiers {
+			req.PlanValue = resp.PlanValue
+			m.PlanModifyString(ctx, req, &resp)
+		}
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%s plan modifiers = %v", name, resp.Diagnostics)
+		}
+		replace = replace || resp.RequiresReplace
+		if diags := plan.SetAttribute(ctx, path.Root(name), resp.PlanValue); diags.HasError() {
+			t.Fatalf("Plan.SetAttribute(%s) = %v", name, diags)
+		}
+	}
+
+	resp := resource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, resource.ModifyPlanRequest{Config: config, State: state, Plan: plan}, &resp)
+	replace = replace || len(resp.RequiresReplace) > 0
+
+	var gitUserID types.String
+	resp.Plan.GetAttribute(ctx, path.Root("git_user_id"), &gitUserID)
+	return replace, gitUserID, resp.Diagnostics
+}
+
+// planGitHubIDChange plans a change of github_id from prior to next on an
+// assigned seat. It reports whether replacement is planned and the planned
+// git_user_id.
+func planGitHubIDChange(t *testing.T, prior, next string) (bool, types.String) {
+	t.Helper()
+
+	state := tfsdk.State{Schema: seatsSchema(), Raw: seatsValue(t, map[string]tftypes.Value{
+		"id":            str("github:1"),
+		"provider_type": str("github"),
+		"github_id":     str(prior),
+		"git_user_id":   str("1"),
+	})}
+	replace, gitUserID, diags := planSeatChange(t, &SeatsResource{}, state, map[string]tftypes.Value{
+		"github_id": str(next),
+	})
+	if diags.HasError() {
+		t.Fatalf("ModifyPlan() = %v", diags)
+	}
+	return replace, gitUserID
+}
+
+func TestSeatsGitHubIDCaseChangeKeepsSeat(t *testing.T) {
+	replace, gitUserID := planGitHubIDChange(t, "Octocat", "octocat")
+	if replace {
+		t.Error("changing github_id casing plans a replacement, want an in-place update")
+	}
+	if gitUserID.ValueString() != "1" {
+		t.Errorf("planned git_user_id = %s, want the resolved 1 kept from state", gitUserID)
+	}
+
+	replace, gitUserID = planGitHubIDChange(t, "octocat", "hubot")
+	if !replace {
+		t.Error("changing github_id to another user doesn't plan a replacement")
+	}
+	if !gitUserID.IsUnknown() {
+		t.Errorf("planned git_user_id = %s, want unknown so the new user is resolved", gitUserID)
+	}
+}
+
+// deleteSeat runs Delete on r for the seat of gitUserID
+func deleteSeat(t *testing.T, r *SeatsResource, gitUserID string) {
+	t.Helper()
+
+	ctx := context.Background()
+	s := seatsSchema()
+	state := tfsdk.State{Schema: s, Raw: seatsValue(t, map[string]tftypes.Value{
+		"id":               str("github:" + gitUserID),
+		"git_user_id":      str(gitUserID),
+		"provider_type":    str("github"),
+		"prevent_unassign": tftypes.NewValue(tftypes.Bool, false),
+	})}
+	resp := resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Delete() = %v", resp.Diagnostics)
+	}
+}
+
+func TestSeatsDeleteWithStaleCache(t *testing.T) {
+	api := newFakeAPI(nil)
+	r := &SeatsResource{client: newTestClient(t, api)}
+
+	// Cache a seats list without the seat, then assign it outside Terraform
+	if _, err := r.client.GetSeats(context.Background()); err != nil {
+		t.Fatalf("GetSeats() error = %v", err)
+	}
+	api.mu.Lock()
+	api.assigned["1"] = true
+	api.mu.Unlock()
+
+	deleteSeat(t, r, "1")
+	if got := api.mutations(); !reflect.DeepEqual(got, []string{"unassign 1"}) {
+		t.Errorf("mutations = %v, want the seat unassigned despite the stale cache", got)
+	}
+	if got := api.seats(); len(got) != 0 {
+		t.Errorf("seats after Delete = %v, want none", got)
+	}
+}
+
+func TestSeatsDeleteAlreadyUnassigned(t *testing.T) {
+	api := newFakeAPI(nil)
+	r := &SeatsResource{client: newTestClient(t, api)}
+
+	deleteSeat(t, r, "1")
+	if got := api.mutations(); len(got) != 0 {
+		t.Errorf("mutations = %v, want none for a seat that is already gone", got)
+	}
+}
+
+// readSeat runs Read on r for the assigned seat of gitUserID and reports
+// whether the resource was removed from state
+func readSeat(t *testing.T, r *SeatsResource, gitUserID string) (bool, diag.Diagnostics) {
+	t.Helper()
+
+	ctx := context.Background()
+	s := seatsSchema()
+	state := tfsdk.State{Schema: s, Raw: seatsValue(t, map[string]tftypes.Value{
+		"id":               str("github:" + gitUserID),
+		"git_user_id":      str(gitUserID),
+		"provider_type":    str("github"),
+		"assigned_at":      str("2024-01-01T00:00:00Z"),
+		"prevent_unassign": tftypes.NewValue(tftypes.Bool, false),
+	})}
+	resp := resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, &resp)
+	return resp.State.Raw.IsNull(), resp.Diagnostics
+}
+
+func TestSeatsReadRemovesOnlyMissingSeats(t *testing.T) {
+	// A seat missing from the list is gone
+	removed, diags := readSeat(t, &SeatsResource{client: newTestClient(t, newFakeAPI(nil, "2"))}, "1")
+	if diags.HasError() || !removed {
+		t.Errorf("Read() of a missing seat removed = %v, diagnostics = %v, want removed without errors", removed, diags)
+	}
+
+	removed, diags = readSeat(t, &SeatsResource{client: newTestClient(t, newFakeAPI(nil, "1"))}, "1")
+	if diags.HasError() || removed {
+		t.Errorf("Read() of an assigned seat removed = %v, diagnostics = %v, want it kept", removed, diags)
+	}
+
+	// A 404 for a later page is a failed read and must not orphan the seat
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("cursor") == "" {
+			_, _ = w.Write([]byte(`{"users":[{"git_user_id":"2","seat_assigned":true}],"next_cursor":"page 2"}`))
+			return
+		}
+		http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+	}))
+	removed, diags = readSeat(t, &SeatsResource{client: c}, "1")
+	if !diags.HasError() || removed {
+		t.Errorf("Read() with a 404 for page 2 removed = %v, diagnostics = %v, want an error and the seat kept", removed, diags)
+	}
+}
+
+func TestSeatsGitHubIDAfterImport(t *testing.T) {
+	logins := map[string]string{"octocat": "1", "hubot": "2"}
+
+	tests := []struct {
+		name        string
+		importID    string
+		githubID    string
+		wantReplace bool
+		wantErr     bool
+	}{
+		{name: "git_user_id import, same user", importID: "1", githubID: "octocat"},
+		{name: "git_user_id import, other casing", importID: "1", githubID: "OctoCat"},
+		{name: "resource ID import, same user", importID: "github:1", githubID: "octocat"},
+		{name: "username import, same user", importID: "octocat", githubID: "octocat"},
+		{name: "git_user_id import, other user", importID: "1", githubID: "hubot", wantReplace: true},
+		{name: "username import, other user", importID: "octocat", githubID: "hubot", wantReplace: true},
+		{name: "git_user_id import, unknown user", importID: "1", githubID: "ghost", wantErr: true},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeAPI(logins, "1")
+			imported, ok := importSeat(t, api, tt.importID)
+			if !ok {
+				t.Fatalf("ImportState(%q) failed", tt.importID)
+			}
+			state := tfsdk.State{Schema: seatsSchema()}
+			state.Raw = tftypes.NewValue(state.Schema.Type().TerraformType(ctx), nil)
+			if diags := state.Set(ctx, &imported); diags.HasError() {
+				t.Fatalf("State.Set() = %v", diags)
+			}
+
+			r := &SeatsResource{client: newTestClient(t, api)}
+			replace, gitUserID, diags := planSeatChange(t, r, state, map[string]tftypes.Value{
+				"github_id": str(tt.githubID),
+			})
+			if diags.HasError() != tt.wantErr {
+				t.Fatalf("ModifyPlan() diagnostics = %v, wantErr %v", diags, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if replace != tt.wantReplace {
+				t.Errorf("replacement planned = %v, want %v", replace, tt.wantReplace)
+			}
+			if tt.wantReplace && !gitUserID.IsUnknown() {
+				t.Errorf("planned git_user_id = %s, want unknown so the new user is resolved", gitUserID)
+			}
+			if !tt.wantReplace && gitUserID.ValueString() != "1" {
+				t.Errorf("planned git_user_id = %s, want 1 kept from state", gitUserID)
+			}
+			if got := api.mutations(); len(got) != 0 {
+				t.Errorf("mutations = %v, want none while planning", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/coderabbitai/terraform-provider-coderabbit/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.ResourceWithUpgradeState = &SeatsResource{}
+
+// seatsSchemaVersion is the current version of the coderabbit_seats schema.
+// Version 2 changed the id from the bare git_user_id to <provider_type>:<git_user_id>.
+const seatsSchemaVersion = 2
+
+// UpgradeState migrates coderabbit_seats state written by older schema versions
+func (r *SeatsResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// v0 states were written while attributes were still being added, so
+		// they are decoded from raw JSON rather than a fixed prior schema
+		0: {
+			StateUpgrader: upgradeSeatsStateV0,
+		},
+		1: {
+			StateUpgrader: upgradeSeatsStateV1,
+		},
+	}
+}
+
+// upgradeSeatsStateV0 carries v0 state over to the current version. v0 and
+// v1 share the same attributes, so only the id needs rewriting.
+func upgradeSeatsStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	upgradeSeatsStateV1(ctx, req, resp)
+}
+
+// upgradeSeatsStateV1 rewrites the legacy bare git_user_id id as
+// <provider_type>:<git_user_id>. Attributes missing from older states are left
+// null and backfilled by Read.
+func upgradeSeatsStateV1(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	if req.RawState == nil || req.RawState.JSON == nil {
+		resp.Diagnostics.AddError(
+			"Unable to Upgrade Resource State",
+			"The prior coderabbit_seats state is not in JSON format. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(req.RawState.JSON, &raw); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Upgrade Resource State",
+			fmt.Sprintf("Could not decode the prior coderabbit_seats state: %s", err.Error()),
+		)
+		return
+	}
+
+	var data SeatsResourceModel
+	for _, attr := range []struct {
+		name  string
+		value *types.String
+	}{
+		{"id", &data.ID},
+		{"github_id", &data.GitHubID},
+		{"git_user_id", &data.GitUserID},
+		{"email", &data.Email},
+		{"provider_type", &data.ProviderType},
+		{"github_login", &data.GitHubLogin},
+		{"assigned_at", &data.AssignedAt},
+		{"role", &data.Role},
+	} {
+		var value *string
+		if err := json.Unmarshal(rawAttribute(raw, attr.name), &value); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Upgrade Resource State",
+				fmt.Sprintf("Could not decode %s of the prior coderabbit_seats state: %s", attr.name, err.Error()),
+			)
+			return
+		}
+		*attr.value = types.StringPointerValue(value)
+	}
+
+	// prevent_unassign defaults to false, also for states written before it existed
+	var preventUnassign bool
+	if err := json.Unmarshal(rawAttribute(raw, "prevent_unassign"), &preventUnassign); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Upgrade Resource State",
+			fmt.Sprintf("Could not decode prevent_unassign of the prior coderabbit_seats state: %s", err.Error()),
+		)
+		return
+	}
+	data.PreventUnassign = types.BoolValue(preventUnassign)
+
+	if _, _, ok := parseSeatID(data.ID.ValueString()); !ok && !data.ID.IsNull() {
+		providerType := client.ProviderTypeGitHub
+		if !data.ProviderType.IsNull() {
+			providerType = data.ProviderType.ValueString()
+		}
+		data.ID = types.StringValue(seatID(providerType, data.ID.ValueString()))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// rawAttribute returns the JSON value of a prior state attribute, or null if
+// the state predates it
+func rawAttribute(raw map[string]json.RawMessage, name string) json.RawMessage {
+	if value, ok := raw[name]; ok {
+		return value
+	}
+	return json.RawMessage("null")
+}
@@ -0,0 +1,99 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// upgradeSeatsState runs the coderabbit_seats state upgrader for version on a
+// raw JSON state
+func upgradeSeatsState(t *testing.T, version int64, state string) (SeatsResourceModel, bool) {
+	t.Helper()
+
+	ctx := context.Background()
+	upgrader, ok := (&SeatsResource{}).UpgradeState(ctx)[version]
+	if !ok {
+		t.Fatalf("no state upgrader for version %d", version)
+	}
+
+	s := seatsSchema()
+	req := resource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: []byte(state)}}
+	resp := resource.UpgradeStateResponse{
+		State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)},
+	}
+	upgrader.StateUpgrader(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Logf("UpgradeState(%d) = %v", version, resp.Diagnostics)
+		return SeatsResourceModel{}, false
+	}
+
+	var data SeatsResourceModel
+	if diags := resp.State.Get(ctx, &data); diags.HasError() {
+		t.Fatalf("State.Get() = %v", diags)
+	}
+	return data, true
+}
+
+func TestUpgradeSeatsStateV0(t *testing.T) {
+	data, ok := upgradeSeatsState(t, 0, `{"id":"12345","github_id":"octocat","git_user_id":"12345","assigned_at":"2024-01-01T00:00:00Z"}`)
+	if !ok {
+		t.Fatal("UpgradeState(0) failed")
+	}
+
+	if got := data.ID.ValueString(); got != "github:12345" {
+		t.Errorf("id = %q, want github:12345", got)
+	}
+	if got := data.GitHubID.ValueString(); got != "octocat" {
+		t.Errorf("github_id = %q, want octocat", got)
+	}
+	if got := data.GitUserID.ValueString(); got != "12345" {
+		t.Errorf("git_user_id = %q, want 12345", got)
+	}
+	if got := data.AssignedAt.ValueString(); got != "2024-01-01T00:00:00Z" {
+		t.Errorf("assigned_at = %q, want 2024-01-01T00:00:00Z", got)
+	}
+	if !data.Email.IsNull() || !data.ProviderType.IsNull() || !data.Role.IsNull() {
+		t.Errorf("attributes missing from v0 = %+v, want null until Read backfills them", data)
+	}
+	if data.PreventUnassign.IsNull() || data.PreventUnassign.ValueBool() {
+		t.Errorf("prevent_unassign = %s, want false", data.PreventUnassign)
+	}
+}
+
+func TestUpgradeSeatsStateV1(t *testing.T) {
+	data, ok := upgradeSeatsState(t, 1, `{"id":"4567","github_id":"octocat","git_user_id":"4567","email":null,"provider_type":"gitlab","role":"admin","prevent_unassign":true,"assigned_at":"2024-01-01T00:00:00Z"}`)
+	if !ok {
+		t.Fatal("UpgradeState(1) failed")
+	}
+
+	if got := data.ID.ValueString(); got != "gitlab:4567" {
+		t.Errorf("id = %q, want gitlab:4567", got)
+	}
+	if got := data.Role.ValueString(); got != "admin" {
+		t.Errorf("role = %q, want admin", got)
+	}
+	if !data.PreventUnassign.ValueBool() {
+		t.Error("prevent_unassign = false, want true carried over")
+	}
+}
+
+func TestUpgradeSeatsStateKeepsCurrentID(t *testing.T) {
+	data, ok := upgradeSeatsState(t, 1, `{"id":"github:12345","git_user_id":"12345"}`)
+	if !ok {
+		t.Fatal("UpgradeState(1) failed")
+	}
+	if got := data.ID.ValueString(); got != "github:12345" {
+		t.Errorf("id = %q, want github:12345 unchanged", got)
+	}
+}
+
+func TestUpgradeSeatsStateInvalidJSON(t *testing.T) {
+	if _, ok := upgradeSeatsState(t, 0, `{"id":12345}`); ok {
+		t.Error("UpgradeState(0) with a numeric id succeeded, want an error")
+	}
+}
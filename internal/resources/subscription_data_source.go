@@ -0,0 +1,111 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/coderabbitai/terraform-provider-coderabbit/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &SubscriptionDataSource{}
+	_ datasource.DataSourceWithConfigure = &SubscriptionDataSource{}
+)
+
+// SubscriptionDataSource defines the subscription data source implementation
+type SubscriptionDataSource struct {
+	client *client.Client
+}
+
+// SubscriptionDataSourceModel describes the subscription data source data model
+type SubscriptionDataSourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Plan      types.String `tfsdk:"plan"`
+	SeatLimit types.Int64  `tfsdk:"seat_limit"`
+	SeatsUsed types.Int64  `tfsdk:"seats_used"`
+}
+
+// NewSubscriptionDataSource creates a new subscription data source
+func NewSubscriptionDataSource() datasource.DataSource {
+	return &SubscriptionDataSource{}
+}
+
+func (d *SubscriptionDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_subscription"
+}
+
+func (d *SubscriptionDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the CodeRabbit subscription plan and seat usage.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The unique identifier for this data source.",
+				Computed:    true,
+			},
+			"plan": schema.StringAttribute{
+				Description: "The subscription plan name.",
+				Computed:    true,
+			},
+			"seat_limit": schema.Int64Attribute{
+				Description: "Total number of seats the subscription allows.",
+				Computed:    true,
+			},
+			"seats_used": schema.Int64Attribute{
+				Description: "Number of seats currently assigned.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *SubscriptionDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = c
+}
+
+func (d *SubscriptionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SubscriptionDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	subscription, err := d.client.GetSubscription(ctx)
+	if err != nil {
+		if client.IsNotFound(err) {
+			resp.Diagnostics.AddError(
+				"Subscription Information Unavailable",
+				"The CodeRabbit API does not expose subscription information for this organization's plan.",
+			)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Subscription",
+			fmt.Sprintf("Could not read subscription: %s", err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue("subscription")
+	data.Plan = types.StringValue(subscription.Plan)
+	data.SeatLimit = types.Int64Value(subscription.SeatLimit)
+	data.SeatsUsed = types.Int64Value(subscription.SeatsUsed)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
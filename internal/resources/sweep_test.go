@@ -0,0 +1,146 @@
+package resources
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/coderabbitai/terraform-provider-coderabbit/internal/client"
+)
+
+// The sweeper cleans up seats that failed acceptance tests left assigned in
+// a real organization. terraform-plugin-testing's sweepers would normally do
+// this, but the module doesn't depend on it, so TestMain provides the same
+// -sweep entry point:
+//
+//	go test ./internal/resources -sweep [-sweep-prefix tf-acc-]
+//
+// It needs CODERABBITAI_API_KEY and GITHUB_TOKEN, and optionally
+// CODERABBIT_BASE_URL.
+var (
+	sweep       = flag.Bool("sweep", false, "unassign seats of acceptance test users instead of running tests")
+	sweepPrefix = flag.String("sweep-prefix", "tf-acc-", "GitHub login prefix of the acceptance test users whose seats -sweep unassigns")
+)
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	if *sweep {
+		os.Exit(runSweep())
+	}
+	os.Exit(m.Run())
+}
+
+// runSweep unassigns the seats of test users in the organization of
+// CODERABBITAI_API_KEY and returns the process exit code
+func runSweep() int {
+	apiKey := os.Getenv("CODERABBITAI_API_KEY")
+	githubToken := os.Getenv("GITHUB_TOKEN")
+	if apiKey == "" || githubToken == "" {
+		fmt.Fprintln(os.Stderr, "sweep: CODERABBITAI_API_KEY and GITHUB_TOKEN must be set")
+		return 1
+	}
+	baseURL := strings.TrimRight(os.Getenv("CODERABBIT_BASE_URL"), "/")
+	if baseURL == "" {
+		baseURL = "https://api.coderabbit.ai"
+	}
+
+	c := client.NewClient(apiKey, baseURL, githubToken, "sweep")
+	defer c.Close()
+
+	swept, err := sweepSeats(context.Background(), c, *sweepPrefix)
+	for _, login := range swept {
+		fmt.Printf("sweep: unassigned the seat of %s\n", login)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "sweep: %s\n", err)
+		return 1
+	}
+	fmt.Printf("sweep: unassigned %d seat(s) of users prefixed %q\n", len(swept), *sweepPrefix)
+	return 0
+}
+
+// sweepSeats unassigns every assigned seat whose GitHub login starts with
+// prefix, ignoring case, and returns the swept logins, sorted. Seats whose
+// login can't be resolved are left alone, since they can't be told apart
+// from real users. An empty prefix is rejected so that a sweep can never
+// unassign the whole organization.
+func sweepSeats(ctx context.Context, c *client.Client, prefix string) ([]string, error) {
+	if prefix == "" {
+		return nil, fmt.Errorf("refusing to sweep without a login prefix")
+	}
+
+	seats, err := c.GetSeats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not list seats: %w", err)
+	}
+
+	var swept []string
+	var errs []string
+	for _, user := range seats.Users {
+		if !user.SeatAssigned {
+			continue
+		}
+
+		login, err := c.GetGitHubLogin(ctx, user.GitUserID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return swept, err
+			}
+			continue
+		}
+		if !strings.HasPrefix(strings.ToLower(login), strings.ToLower(prefix)) {
+			continue
+		}
+
+		if err := c.UnassignSeat(ctx, user.GitUserID); err != nil {
+			errs = append(errs, fmt.Sprintf("%s (git_user_id %s): %s", login, user.GitUserID, err))
+			continue
+		}
+		swept = append(swept, login)
+	}
+	sort.Strings(swept)
+
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return swept, fmt.Errorf("could not unassign %d seat(s): %s", len(errs), strings.Join(errs, "; "))
+	}
+	return swept, nil
+}
+
+func TestSweepSeats(t *testing.T) {
+	api := newFakeAPI(map[string]string{
+		"tf-acc-alice": "1",
+		"TF-ACC-bob":   "2",
+		"carol":        "3",
+		"tf-acc-dave":  "4",
+	}, "1", "2", "3", "5")
+	c := newTestClient(t, api)
+
+	swept, err := sweepSeats(context.Background(), c, "tf-acc-")
+	if err != nil {
+		t.Fatalf("sweepSeats() error = %v", err)
+	}
+	if want := []string{"TF-ACC-bob", "tf-acc-alice"}; !reflect.DeepEqual(swept, want) {
+		t.Errorf("sweepSeats() = %v, want %v", swept, want)
+	}
+
+	// carol isn't a test user, tf-acc-dave has no seat and 5 can't be resolved
+	if got, want := api.seats(), []string{"3", "5"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("seats after sweep = %v, want %v", got, want)
+	}
+}
+
+func TestSweepSeatsRequiresPrefix(t *testing.T) {
+	api := newFakeAPI(map[string]string{"carol": "3"}, "3")
+	if _, err := sweepSeats(context.Background(), newTestClient(t, api), ""); err == nil {
+		t.Error("sweepSeats() without a prefix error = nil, want a refusal")
+	}
+	if got := api.mutations(); len(got) != 0 {
+		t.Errorf("mutations = %v, want none", got)
+	}
+}
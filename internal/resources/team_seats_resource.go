@@ -0,0 +1,330 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/coderabbitai/terraform-provider-coderabbit/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &TeamSeatsResource{}
+	_ resource.ResourceWithConfigure   = &TeamSeatsResource{}
+	_ resource.ResourceWithModifyPlan  = &TeamSeatsResource{}
+	_ resource.ResourceWithImportState = &TeamSeatsResource{}
+)
+
+// TeamSeatsResource defines the team seats resource implementation
+type TeamSeatsResource struct {
+	client *client.Client
+}
+
+// TeamSeatsResourceModel describes the team seats resource data model
+type TeamSeatsResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Org         types.String `tfsdk:"org"`
+	TeamSlug    types.String `tfsdk:"team_slug"`
+	Concurrency types.Int64  `tfsdk:"concurrency"`
+	GitUserIDs  types.Map    `tfsdk:"git_user_ids"`
+}
+
+// NewTeamSeatsResource creates a new team seats resource
+func NewTeamSeatsResource() resource.Resource {
+	return &TeamSeatsResource{}
+}
+
+func (r *TeamSeatsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_team_seats"
+}
+
+func (r *TeamSeatsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Assigns CodeRabbit seats to every member of a GitHub team. Membership is re-read on each plan, so members who join or leave the team between applies gain or lose their seat. Requires GitHub authentication (a github_token with the read:org scope, or a GitHub App). Failures for individual users are reported as warnings and retried on the next apply.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The unique identifier for this resource, in the form <org>/<team_slug>.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"org": schema.StringAttribute{
+				Description: "The GitHub organization that owns the team.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"team_slug": schema.StringAttribute{
+				Description: "The slug of the GitHub team, e.g. backend. Members of child teams are included.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"concurrency": schema.Int64Attribute{
+				Description: "Maximum number of parallel assign/unassign API calls. Defaults to 8.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(client.DefaultBulkConcurrency),
+				Validators: []validator.Int64{
+					int64AtLeast(1),
+				},
+			},
+			"git_user_ids": schema.MapAttribute{
+				Description: "Map of GitHub login to numeric git_user_id for every team member that currently has a seat managed by this resource.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (r *TeamSeatsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = c
+}
+
+// ModifyPlan re-reads the team membership and plans an update when it no
+// longer matches the seats managed by this resource
+func (r *TeamSeatsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check on destroy or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	warnMissingGitHubScopes(ctx, r.client, &resp.Diagnostics, "coderabbit_team_seats needs read:org to list team members", "read:org")
+
+	// Nothing to compare on create
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state TeamSeatsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() || plan.GitUserIDs.IsUnknown() {
+		return
+	}
+
+	members, err := r.client.GetGitHubTeamMembers(ctx, plan.Org.ValueString(), plan.TeamSlug.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading GitHub Team",
+			fmt.Sprintf("Could not list members of GitHub team %s: %s", teamID(plan), err.Error()),
+		)
+		return
+	}
+
+	managed, diags := gitUserIDsMap(ctx, state.GitUserIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !sameGitUserIDs(teamMembersMap(members), managed) {
+		plan.GitUserIDs = types.MapUnknown(types.StringType)
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+	}
+}
+
+func (r *TeamSeatsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data TeamSeatsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	managed := r.reconcile(ctx, data, map[string]string{}, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(teamID(data))
+	resp.Diagnostics.Append(setGitUserIDs(ctx, &data.GitUserIDs, managed)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TeamSeatsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data TeamSeatsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	managed, diags := gitUserIDsMap(ctx, data.GitUserIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	assigned, err := assignedSeats(ctx, r.client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Seats",
+			fmt.Sprintf("Could not read seat assignments: %s", errorDetail(err)),
+		)
+		return
+	}
+
+	// Keep only users that still have a seat, so that seats removed outside
+	// Terraform are assigned again on the next apply
+	resp.Diagnostics.Append(setGitUserIDs(ctx, &data.GitUserIDs, withSeats(managed, assigned))...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TeamSeatsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state TeamSeatsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, diags := gitUserIDsMap(ctx, state.GitUserIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	managed := r.reconcile(ctx, plan, current, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+	resp.Diagnostics.Append(setGitUserIDs(ctx, &plan.GitUserIDs, managed)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *TeamSeatsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data TeamSeatsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	managed, diags := gitUserIDsMap(ctx, data.GitUserIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	unassignManagedSeats(ctx, r.client, managed, int(data.Concurrency.ValueInt64()), &resp.Diagnostics)
+}
+
+// reconcile lists the team's members and reconciles their seats with the
+// current users via reconcileSeats, so that members who joined are assigned
+// a seat before those who left lose theirs
+func (r *TeamSeatsResource) reconcile(ctx context.Context, data TeamSeatsResourceModel, current map[string]string, diags *diag.Diagnostics) map[string]string {
+	members, err := r.client.GetGitHubTeamMembers(ctx, data.Org.ValueString(), data.TeamSlug.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Error Reading GitHub Team",
+			fmt.Sprintf("Could not list members of GitHub team %s: %s", teamID(data), err.Error()),
+		)
+		return nil
+	}
+
+	return reconcileSeats(ctx, r.client, teamMembersMap(members), current, int(data.Concurrency.ValueInt64()), diags)
+}
+
+// ImportState adopts the seats of a team's members, imported with the ID
+// <org>/<team_slug>. Only members that already have a seat are adopted; the
+// rest are assigned on the next apply.
+func (r *TeamSeatsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	org, teamSlug, ok := strings.Cut(req.ID, "/")
+	if !ok || org == "" || teamSlug == "" || strings.Contains(teamSlug, "/") {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected an import ID of the form <org>/<team_slug>, got: %q", req.ID),
+		)
+		return
+	}
+
+	data := TeamSeatsResourceModel{
+		ID:          types.StringValue(req.ID),
+		Org:         types.StringValue(org),
+		TeamSlug:    types.StringValue(teamSlug),
+		Concurrency: types.Int64Value(client.DefaultBulkConcurrency),
+	}
+
+	members, err := r.client.GetGitHubTeamMembers(ctx, org, teamSlug)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("team_slug"),
+			"Error Reading GitHub Team",
+			fmt.Sprintf("Could not list members of GitHub team %s: %s", req.ID, err.Error()),
+		)
+		return
+	}
+
+	assigned, err := assignedSeats(ctx, r.client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Seats",
+			fmt.Sprintf("Could not read seat assignments: %s", errorDetail(err)),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(setGitUserIDs(ctx, &data.GitUserIDs, withSeats(teamMembersMap(members), assigned))...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// teamID returns the <org>/<team_slug> identifier of a team seats resource
+func teamID(data TeamSeatsResourceModel) string {
+	return data.Org.ValueString() + "/" + data.TeamSlug.ValueString()
+}
+
+// teamMembersMap maps each team member's login to their git_user_id
+func teamMembersMap(members []client.GitHubUserResponse) map[string]string {
+	result := make(map[string]string, len(members))
+	for _, member := range members {
+		result[member.Login] = fmt.Sprintf("%d", member.ID)
+	}
+	return result
+}
+
+// sameGitUserIDs reports whether a and b hold the same set of git_user_ids,
+// ignoring logins so that renamed users don't cause a diff
+func sameGitUserIDs(a, b map[string]string) bool {
+	return strings.Join(sortedValues(a), ",") == strings.Join(sortedValues(b), ",")
+}
+
+// sortedValues returns the values of m in sorted order
+func sortedValues(m map[string]string) []string {
+	values := make([]string, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	sort.Strings(values)
+	return values
+}
@@ -0,0 +1,95 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/coderabbitai/terraform-provider-coderabbit/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// teamAPI serves the members of the GitHub team my-org/backend and passes
+// every other request to api
+func teamAPI(api *fakeAPI, members map[string]int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/orgs/my-org/teams/backend/members") {
+			api.ServeHTTP(w, r)
+			return
+		}
+		users := []client.GitHubUserResponse{}
+		for login, id := range members {
+			users = append(users, client.GitHubUserResponse{Login: login, ID: id})
+		}
+		_ = json.NewEncoder(w).Encode(users)
+	})
+}
+
+func TestTeamSeatsReconcile(t *testing.T) {
+	tests := []struct {
+		name          string
+		members       map[string]int
+		assigned      []string
+		current       map[string]string
+		wantManaged   map[string]string
+		wantMutations []string
+	}{
+		{
+			name:          "member replaced",
+			members:       map[string]int{"bob": 2},
+			assigned:      []string{"1"},
+			current:       map[string]string{"alice": "1"},
+			wantManaged:   map[string]string{"bob": "2"},
+			wantMutations: []string{"assign 2", "unassign 1"},
+		},
+		{
+			name:          "member renamed",
+			members:       map[string]int{"alice-renamed": 1},
+			assigned:      []string{"1"},
+			current:       map[string]string{"alice": "1"},
+			wantManaged:   map[string]string{"alice-renamed": "1"},
+			wantMutations: nil,
+		},
+		{
+			name:          "seat removed outside Terraform",
+			members:       map[string]int{"alice": 1},
+			assigned:      nil,
+			current:       map[string]string{"alice": "1"},
+			wantManaged:   map[string]string{"alice": "1"},
+			wantMutations: []string{"assign 1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeAPI(nil, tt.assigned...)
+			c := newTestClient(t, teamAPI(api, tt.members))
+			c.GitHubToken = "test-github-token"
+			r := &TeamSeatsResource{client: c}
+
+			data := TeamSeatsResourceModel{
+				Org:         types.StringValue("my-org"),
+				TeamSlug:    types.StringValue("backend"),
+				Concurrency: types.Int64Value(2),
+			}
+			var diags diag.Diagnostics
+			managed := r.reconcile(context.Background(), data, tt.current, &diags)
+			if diags.HasError() || diags.WarningsCount() > 0 {
+				t.Fatalf("reconcile() diagnostics = %v", diags)
+			}
+
+			if !reflect.DeepEqual(managed, tt.wantManaged) {
+				t.Errorf("reconcile() = %v, want %v", managed, tt.wantManaged)
+			}
+			// Seats are assigned before any are unassigned, so the seat
+			// count never drops below the team's size
+			if got := api.mutations(); !reflect.DeepEqual(got, tt.wantMutations) {
+				t.Errorf("mutations = %v, want %v", got, tt.wantMutations)
+			}
+		})
+	}
+}
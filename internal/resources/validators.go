@@ -0,0 +1,298 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/coderabbitai/terraform-provider-coderabbit/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.ConfigValidator   = exactlyOneOfValidator{}
+	_ datasource.ConfigValidator = exactlyOneOfValidator{}
+)
+
+// exactlyOneOfValidator checks that exactly one group of the given root
+// string attributes is configured. Attributes of the same group may be
+// configured together.
+type exactlyOneOfValidator struct {
+	groups [][]string
+}
+
+// exactlyOneOf returns a config validator requiring exactly one of the given root attributes
+func exactlyOneOf(attributes ...string) exactlyOneOfValidator {
+	groups := make([][]string, 0, len(attributes))
+	for _, name := range attributes {
+		groups = append(groups, []string{name})
+	}
+	return exactlyOneOfValidator{groups: groups}
+}
+
+// exactlyOneOfGroups returns a config validator requiring attributes of
+// exactly one of the given groups, e.g. github_id and git_user_id together or
+// email on its own
+func exactlyOneOfGroups(groups ...[]string) exactlyOneOfValidator {
+	return exactlyOneOfValidator{groups: groups}
+}
+
+func (v exactlyOneOfValidator) Description(ctx context.Context) string {
+	return v.message()
+}
+
+func (v exactlyOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v exactlyOneOfValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	v.validate(ctx, req.Config, &resp.Diagnostics)
+}
+
+func (v exactlyOneOfValidator) ValidateDataSource(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	v.validate(ctx, req.Config, &resp.Diagnostics)
+}
+
+func (v exactlyOneOfValidator) validate(ctx context.Context, config tfsdk.Config, diags *diag.Diagnostics) {
+	configured := 0
+	for _, group := range v.groups {
+		groupConfigured := false
+		for _, name := range group {
+			var value types.String
+			diags.Append(config.GetAttribute(ctx, path.Root(name), &value)...)
+			if diags.HasError() {
+				return
+			}
+
+			// Unknown values may still resolve to null, so defer validation until apply
+			if value.IsUnknown() {
+				return
+			}
+			if !value.IsNull() {
+				groupConfigured = true
+			}
+		}
+		if groupConfigured {
+			configured++
+		}
+	}
+
+	if configured != 1 {
+		diags.AddAttributeError(
+			path.Root(v.groups[0][0]),
+			"Invalid Attribute Combination",
+			v.message(),
+		)
+	}
+}
+
+// message describes the allowed combinations, e.g. "Exactly one of these
+// attributes must be configured: github_id and/or git_user_id, email"
+func (v exactlyOneOfValidator) message() string {
+	choices := make([]string, 0, len(v.groups))
+	for _, group := range v.groups {
+		choices = append(choices, strings.Join(group, " and/or "))
+	}
+	return fmt.Sprintf("Exactly one of these attributes must be configured: %s", strings.Join(choices, ", "))
+}
+
+var _ validator.String = stringOneOfValidator{}
+
+// stringOneOfValidator checks that a string attribute is one of a fixed set of values
+type stringOneOfValidator struct {
+	values []string
+}
+
+// stringOneOf returns a validator requiring the attribute value to be one of values
+func stringOneOf(values ...string) stringOneOfValidator {
+	return stringOneOfValidator{values: values}
+}
+
+func (v stringOneOfValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be one of: %s", strings.Join(v.values, ", "))
+}
+
+func (v stringOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringOneOfValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	for _, allowed := range v.values {
+		if value == allowed {
+			return
+		}
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid Attribute Value",
+		fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), value),
+	)
+}
+
+var _ validator.String = githubUsernameValidator{}
+
+// githubUsernamePattern matches GitHub usernames: up to 39 alphanumerics,
+// hyphens or underscores, starting with an alphanumeric and not ending with
+// a hyphen. Underscores are allowed for Enterprise Managed User handles such
+// as octocat_acme.
+var githubUsernamePattern = regexp.MustCompile(`^[A-Za-z0-9](?:[A-Za-z0-9_-]{0,37}[A-Za-z0-9_])?$`)
+
+// githubUsernameValidator checks that a string attribute is a well-formed
+// GitHub username when the resource's provider_type is github
+type githubUsernameValidator struct{}
+
+// githubUsername returns a validator requiring a well-formed GitHub username
+func githubUsername() githubUsernameValidator {
+	return githubUsernameValidator{}
+}
+
+func (v githubUsernameValidator) Description(ctx context.Context) string {
+	return "value must be a GitHub username of 1-39 letters, digits, hyphens or underscores (for Enterprise Managed Users, e.g. octocat_acme), starting with a letter or digit and not ending with a hyphen"
+}
+
+func (v githubUsernameValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v githubUsernameValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	// GitLab usernames and Bitbucket account IDs use other formats
+	var providerType types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("provider_type"), &providerType)...)
+	if providerType.IsUnknown() || (!providerType.IsNull() && providerType.ValueString() != client.ProviderTypeGitHub) {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if githubUsernamePattern.MatchString(value) {
+		return
+	}
+
+	detail := ""
+	switch {
+	case strings.HasPrefix(value, "@"):
+		detail = " Remove the leading @."
+	case strings.TrimSpace(value) != value:
+		detail = " Remove the surrounding whitespace."
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid GitHub Username",
+		fmt.Sprintf("Attribute %s %s, got: %q.%s", req.Path, v.Description(ctx), value, detail),
+	)
+}
+
+var _ validator.String = numericIDValidator{}
+
+// numericIDValidator checks that a string attribute is a numeric user ID
+type numericIDValidator struct{}
+
+// numericID returns a validator requiring a string of ASCII digits
+func numericID() numericIDValidator {
+	return numericIDValidator{}
+}
+
+func (v numericIDValidator) Description(ctx context.Context) string {
+	return "value must be a numeric user ID"
+}
+
+func (v numericIDValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v numericIDValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() || isNumeric(req.ConfigValue.ValueString()) {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid Git User ID",
+		fmt.Sprintf("Attribute %s %s, got: %q.", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+	)
+}
+
+var _ validator.Int64 = int64AtLeastValidator{}
+
+// int64AtLeastValidator checks that an int64 attribute is at least a minimum value
+type int64AtLeastValidator struct {
+	min int64
+}
+
+// int64AtLeast returns a validator requiring the attribute value to be at least min
+func int64AtLeast(min int64) int64AtLeastValidator {
+	return int64AtLeastValidator{min: min}
+}
+
+func (v int64AtLeastValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be at least %d", v.min)
+}
+
+func (v int64AtLeastValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v int64AtLeastValidator) ValidateInt64(ctx context.Context, req validator.Int64Request, resp *validator.Int64Response) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if req.ConfigValue.ValueInt64() < v.min {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, got: %d", req.Path, v.Description(ctx), req.ConfigValue.ValueInt64()),
+		)
+	}
+}
+
+var _ validator.Int64 = int64AtMostValidator{}
+
+// int64AtMostValidator checks that an int64 attribute is at most a maximum value
+type int64AtMostValidator struct {
+	max int64
+}
+
+// int64AtMost returns a validator requiring the attribute value to be at most max
+func int64AtMost(max int64) int64AtMostValidator {
+	return int64AtMostValidator{max: max}
+}
+
+func (v int64AtMostValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be at most %d", v.max)
+}
+
+func (v int64AtMostValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v int64AtMostValidator) ValidateInt64(ctx context.Context, req validator.Int64Request, resp *validator.Int64Response) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if req.ConfigValue.ValueInt64() > v.max {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, got: %d", req.Path, v.Description(ctx), req.ConfigValue.ValueInt64()),
+		)
+	}
+}
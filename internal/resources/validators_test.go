@@ -0,0 +1,32 @@
+package resources
+
+import "testing"
+
+func TestGitHubUsernamePattern(t *testing.T) {
+	tests := []struct {
+		username string
+		want     bool
+	}{
+		{"octocat", true},
+		{"Octo-Cat", true},
+		{"a", true},
+		{"octocat_acme", true},
+		{"octocat_", true},
+		{"123456", true},
+		{"abcdefghijklmnopqrstuvwxyz0123456789abc", true},
+		{"abcdefghijklmnopqrstuvwxyz0123456789abcd", false},
+		{"", false},
+		{"-octocat", false},
+		{"octocat-", false},
+		{"_octocat", false},
+		{"@octocat", false},
+		{" octocat", false},
+		{"octo.cat", false},
+	}
+
+	for _, tt := range tests {
+		if got := githubUsernamePattern.MatchString(tt.username); got != tt.want {
+			t.Errorf("githubUsernamePattern.MatchString(%q) = %v, want %v", tt.username, got, tt.want)
+		}
+	}
+}